@@ -0,0 +1,126 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors for common API failures. An *APIError matches them with errors.Is:
+//
+//	if errors.Is(err, common.ErrInsufficientLiquidity) { ... }
+var (
+	ErrInsufficientLiquidity = errors.New("insufficient liquidity")
+	ErrInsufficientAllowance = errors.New("insufficient allowance")
+	ErrNotEnoughBalance      = errors.New("not enough balance")
+	ErrRateLimited           = errors.New("rate limited")
+)
+
+// APIError is returned by every SDK client when the 1inch API answers with a non-2xx status.
+// Use errors.As to access it.
+type APIError struct {
+	// StatusCode is the HTTP status of the response
+	StatusCode int
+	// RequestID is the 1inch request id, useful when contacting support
+	RequestID string
+	// ErrorCode is the short error name from the response body, such as "Bad Request"
+	ErrorCode string
+	// Description is the human-readable reason for the failure
+	Description string
+	// Meta holds additional details reported by the API, such as the offending parameter
+	Meta []APIErrorMeta
+	// RawBody is the unmodified response body, which may not be JSON (e.g. gateway HTML pages)
+	RawBody []byte
+	// Endpoint is the method and path of the failed request, such as "GET /swap/v6.1/1/quote"
+	Endpoint string
+}
+
+// APIErrorMeta is a single type/value pair from the meta field of an API error.
+type APIErrorMeta struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// NewAPIError builds an APIError from a failed response. Bodies that are not JSON are kept
+// in RawBody only.
+func NewAPIError(statusCode int, endpoint string, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		Endpoint:   endpoint,
+		RawBody:    body,
+	}
+
+	var decoded struct {
+		Error       string          `json:"error"`
+		Description string          `json:"description"`
+		Message     string          `json:"message"`
+		RequestID   string          `json:"requestId"`
+		Meta        json.RawMessage `json:"meta"`
+	}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return apiErr
+	}
+
+	apiErr.ErrorCode = decoded.Error
+	apiErr.RequestID = decoded.RequestID
+	apiErr.Description = decoded.Description
+	if apiErr.Description == "" {
+		apiErr.Description = decoded.Message
+	}
+	// meta is informational and its shape varies between APIs, so a mismatch is not an error
+	_ = json.Unmarshal(decoded.Meta, &apiErr.Meta)
+
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "1inch API error: status %d", e.StatusCode)
+	if e.Endpoint != "" {
+		fmt.Fprintf(&b, " from %s", e.Endpoint)
+	}
+	switch {
+	case e.Description != "":
+		fmt.Fprintf(&b, ": %s", e.Description)
+	case e.ErrorCode != "":
+		fmt.Fprintf(&b, ": %s", e.ErrorCode)
+	case len(e.RawBody) > 0:
+		fmt.Fprintf(&b, ": %s", truncate(strings.TrimSpace(string(e.RawBody)), 256))
+	default:
+		fmt.Fprintf(&b, ": %s", http.StatusText(e.StatusCode))
+	}
+	for _, m := range e.Meta {
+		fmt.Fprintf(&b, " [%s: %s]", m.Type, m.Value)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (requestId %s)", e.RequestID)
+	}
+	return b.String()
+}
+
+// Is reports whether the error belongs to the class described by one of the sentinel errors.
+func (e *APIError) Is(target error) bool {
+	description := strings.ToLower(e.Description)
+	switch target {
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrInsufficientLiquidity:
+		return strings.Contains(description, "insufficient liquidity")
+	case ErrInsufficientAllowance:
+		return strings.Contains(description, "allowance")
+	case ErrNotEnoughBalance:
+		return strings.Contains(description, "balance") &&
+			(strings.Contains(description, "not enough") || strings.Contains(description, "insufficient"))
+	default:
+		return false
+	}
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
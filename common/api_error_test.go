@@ -0,0 +1,111 @@
+package common
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAPIError(t *testing.T) {
+	tests := []struct {
+		name                string
+		status              int
+		body                string
+		expectedMessage     string
+		expectedDescription string
+	}{
+		{
+			name:                "Full 1inch error body",
+			status:              http.StatusBadRequest,
+			body:                `{"error":"Bad Request","description":"Not enough allowance","requestId":"r-1","meta":[{"type":"spender","value":"0xabc"}]}`,
+			expectedMessage:     "1inch API error: status 400 from GET /quote: Not enough allowance [spender: 0xabc] (requestId r-1)",
+			expectedDescription: "Not enough allowance",
+		},
+		{
+			name:                "Message field used when description is missing",
+			status:              http.StatusInternalServerError,
+			body:                `{"message":"internal server error"}`,
+			expectedMessage:     "1inch API error: status 500 from GET /quote: internal server error",
+			expectedDescription: "internal server error",
+		},
+		{
+			name:            "Unexpected meta shape is ignored",
+			status:          http.StatusBadRequest,
+			body:            `{"error":"Bad Request","meta":{"unexpected":true}}`,
+			expectedMessage: "1inch API error: status 400 from GET /quote: Bad Request",
+		},
+		{
+			name:            "Non-JSON body",
+			status:          http.StatusBadGateway,
+			body:            "<html>bad gateway</html>",
+			expectedMessage: "1inch API error: status 502 from GET /quote: <html>bad gateway</html>",
+		},
+		{
+			name:            "Empty body",
+			status:          http.StatusServiceUnavailable,
+			expectedMessage: "1inch API error: status 503 from GET /quote: Service Unavailable",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewAPIError(tc.status, "GET /quote", []byte(tc.body))
+			assert.Equal(t, tc.expectedMessage, err.Error())
+			assert.Equal(t, tc.expectedDescription, err.Description)
+			assert.Equal(t, tc.status, err.StatusCode)
+		})
+	}
+}
+
+func TestAPIErrorIs(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		description string
+		sentinel    error
+		expected    bool
+	}{
+		{
+			name:        "Insufficient liquidity",
+			status:      http.StatusBadRequest,
+			description: "insufficient liquidity",
+			sentinel:    ErrInsufficientLiquidity,
+			expected:    true,
+		},
+		{
+			name:        "Not enough allowance",
+			status:      http.StatusBadRequest,
+			description: "Not enough allowance. Amount: 100. Allowance: 0.",
+			sentinel:    ErrInsufficientAllowance,
+			expected:    true,
+		},
+		{
+			name:        "Not enough balance",
+			status:      http.StatusBadRequest,
+			description: "Not enough 0xa0b8 balance. Amount: 100. Balance: 0.",
+			sentinel:    ErrNotEnoughBalance,
+			expected:    true,
+		},
+		{
+			name:     "Rate limited",
+			status:   http.StatusTooManyRequests,
+			sentinel: ErrRateLimited,
+			expected: true,
+		},
+		{
+			name:        "Unrelated description",
+			status:      http.StatusBadRequest,
+			description: "src is not a valid address",
+			sentinel:    ErrInsufficientLiquidity,
+			expected:    false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := &APIError{StatusCode: tc.status, Description: tc.description}
+			assert.Equal(t, tc.expected, err.Is(tc.sentinel))
+		})
+	}
+}
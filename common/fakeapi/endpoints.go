@@ -0,0 +1,110 @@
+package fakeapi
+
+// Endpoint identifies a 1inch API route by method and URL path pattern. Pattern uses
+// path.Match syntax, so "*" matches a single path segment such as an API version or chain ID,
+// extended with "{a,b}", which matches any of the listed alternatives.
+type Endpoint struct {
+	Method  string
+	Pattern string
+}
+
+func (e Endpoint) String() string {
+	return e.Method + " " + e.Pattern
+}
+
+// Aggregation (swap) endpoints.
+var (
+	SwapQuote              = Endpoint{Method: "GET", Pattern: "/swap/*/*/quote"}
+	SwapSwap               = Endpoint{Method: "GET", Pattern: "/swap/*/*/swap"}
+	SwapApproveSpender     = Endpoint{Method: "GET", Pattern: "/swap/*/*/approve/spender"}
+	SwapApproveTransaction = Endpoint{Method: "GET", Pattern: "/swap/*/*/approve/transaction"}
+	SwapApproveAllowance   = Endpoint{Method: "GET", Pattern: "/swap/*/*/approve/allowance"}
+	SwapTokens             = Endpoint{Method: "GET", Pattern: "/swap/*/*/tokens"}
+	SwapLiquiditySources   = Endpoint{Method: "GET", Pattern: "/swap/*/*/liquidity-sources"}
+)
+
+// Fusion endpoints.
+var (
+	FusionQuote             = Endpoint{Method: "GET", Pattern: "/fusion/quoter/*/*/quote/receive"}
+	FusionQuoteCustomPreset = Endpoint{Method: "POST", Pattern: "/fusion/quoter/*/*/quote/receive"}
+	FusionSubmitOrder       = Endpoint{Method: "POST", Pattern: "/fusion/relayer/*/*/order/submit"}
+	FusionSubmitOrders      = Endpoint{Method: "POST", Pattern: "/fusion/relayer/*/*/order/submit/many"}
+	FusionActiveOrders      = Endpoint{Method: "GET", Pattern: "/fusion/orders/*/*/order/active"}
+	FusionOrderStatus       = Endpoint{Method: "GET", Pattern: "/fusion/orders/*/*/order/status/*"}
+	FusionSettlement        = Endpoint{Method: "GET", Pattern: "/fusion/orders/*/*/order/settlement"}
+)
+
+// Fusion plus endpoints.
+var (
+	FusionPlusQuote              = Endpoint{Method: "GET", Pattern: "/fusion-plus/quoter/*/quote/receive"}
+	FusionPlusSubmitOrder        = Endpoint{Method: "POST", Pattern: "/fusion-plus/relayer/*/submit"}
+	FusionPlusSubmitSecret       = Endpoint{Method: "POST", Pattern: "/fusion-plus/relayer/*/submit/secret"}
+	FusionPlusActiveOrders       = Endpoint{Method: "GET", Pattern: "/fusion-plus/orders/*/order/active"}
+	FusionPlusOrderStatus        = Endpoint{Method: "GET", Pattern: "/fusion-plus/orders/*/order/status/*"}
+	FusionPlusReadyToAcceptFills = Endpoint{Method: "GET", Pattern: "/fusion-plus/orders/*/order/ready-to-accept-secret-fills/*"}
+	FusionPlusEscrowFactory      = Endpoint{Method: "GET", Pattern: "/fusion-plus/orders/*/order/escrow"}
+)
+
+// Orderbook endpoints.
+var (
+	OrderbookCreateOrder     = Endpoint{Method: "POST", Pattern: "/orderbook/*/*"}
+	OrderbookOrder           = Endpoint{Method: "GET", Pattern: "/orderbook/*/*/order/*"}
+	OrderbookOrdersByCreator = Endpoint{Method: "GET", Pattern: "/orderbook/*/*/address/*"}
+	OrderbookAllOrders       = Endpoint{Method: "GET", Pattern: "/orderbook/*/*/all"}
+	OrderbookOrderCount      = Endpoint{Method: "GET", Pattern: "/orderbook/*/*/count"}
+	OrderbookFeeInfo         = Endpoint{Method: "GET", Pattern: "/orderbook/*/*/fee-info"}
+)
+
+// Balance endpoints.
+var (
+	BalancesByWallet                   = Endpoint{Method: "GET", Pattern: "/balance/*/*/balances/*"}
+	BalancesOfCustomTokens             = Endpoint{Method: "POST", Pattern: "/balance/*/*/balances/*"}
+	BalancesOfCustomTokensByWallets    = Endpoint{Method: "POST", Pattern: "/balance/*/*/balances/multiple/walletsAndTokens"}
+	BalancesAndAllowances              = Endpoint{Method: "GET", Pattern: "/balance/*/*/allowancesAndBalances/*/*"}
+	BalancesAndAllowancesOfCustomToken = Endpoint{Method: "POST", Pattern: "/balance/*/*/allowancesAndBalances/*/*"}
+	BalancesAndAllowancesAggregated    = Endpoint{Method: "GET", Pattern: "/balance/*/*/aggregatedBalancesAndAllowances/*"}
+	AllowancesByWallet                 = Endpoint{Method: "GET", Pattern: "/balance/*/*/allowances/*/*"}
+	AllowancesOfCustomTokens           = Endpoint{Method: "POST", Pattern: "/balance/*/*/allowances/*/*"}
+)
+
+// Spot price endpoints.
+var (
+	SpotPricesWhitelisted    = Endpoint{Method: "GET", Pattern: "/price/*/*"}
+	SpotPricesCurrencies     = Endpoint{Method: "GET", Pattern: "/price/*/*/currencies"}
+	SpotPricesRequested      = Endpoint{Method: "GET", Pattern: "/price/*/*/*"}
+	SpotPricesRequestedLarge = Endpoint{Method: "POST", Pattern: "/price/*/*"}
+)
+
+// Token endpoints.
+var (
+	TokensSearchAllChains = Endpoint{Method: "GET", Pattern: "/token/*/search"}
+	TokensSearch          = Endpoint{Method: "GET", Pattern: "/token/*/*/search"}
+	TokensWhitelisted     = Endpoint{Method: "GET", Pattern: "/token/*/*"}
+	TokensWhitelistedList = Endpoint{Method: "GET", Pattern: "/token/*/*/token-list"}
+	TokensCustom          = Endpoint{Method: "GET", Pattern: "/token/*/*/custom"}
+	TokensCustomToken     = Endpoint{Method: "GET", Pattern: "/token/*/*/custom/*"}
+)
+
+// Gas price endpoints. GasPriceLegacy serves the chains without EIP-1559 (BSC, Fantom, zkSync
+// Era and Aurora) and GasPrice every other chain.
+var (
+	GasPriceLegacy = Endpoint{Method: "GET", Pattern: "/gas-price/*/{56,250,324,1313161554}"}
+	GasPrice       = Endpoint{Method: "GET", Pattern: "/gas-price/*/*"}
+)
+
+// routes lists every emulated endpoint in matching order: when several patterns match a
+// request, the one listed first wins.
+var routes = []Endpoint{
+	SwapQuote, SwapSwap, SwapApproveSpender, SwapApproveTransaction, SwapApproveAllowance, SwapTokens, SwapLiquiditySources,
+	FusionQuote, FusionQuoteCustomPreset, FusionSubmitOrder, FusionSubmitOrders, FusionActiveOrders, FusionOrderStatus, FusionSettlement,
+	FusionPlusQuote, FusionPlusSubmitOrder, FusionPlusSubmitSecret, FusionPlusActiveOrders, FusionPlusOrderStatus, FusionPlusReadyToAcceptFills, FusionPlusEscrowFactory,
+	OrderbookCreateOrder, OrderbookOrder, OrderbookOrdersByCreator, OrderbookAllOrders, OrderbookOrderCount, OrderbookFeeInfo,
+	BalancesByWallet, BalancesOfCustomTokens, BalancesOfCustomTokensByWallets, BalancesAndAllowances, BalancesAndAllowancesOfCustomToken,
+	BalancesAndAllowancesAggregated, AllowancesByWallet, AllowancesOfCustomTokens,
+	SpotPricesWhitelisted, SpotPricesCurrencies, SpotPricesRequested, SpotPricesRequestedLarge,
+	TokensSearchAllChains, TokensSearch, TokensWhitelisted, TokensWhitelistedList, TokensCustom, TokensCustomToken,
+	GasPriceLegacy, GasPrice,
+}
+
+// orderSubmissionEndpoints are the endpoints whose requests SubmittedOrders returns.
+var orderSubmissionEndpoints = []Endpoint{FusionSubmitOrder, FusionSubmitOrders, FusionPlusSubmitOrder, OrderbookCreateOrder}
@@ -0,0 +1,226 @@
+package fakeapi
+
+import "net/http"
+
+// Addresses used by the default fixtures.
+const (
+	RouterAddress           = "0x111111125421ca6dc452d289314280a0f8842a65"
+	FusionSettlementAddress = "0xfb2809a5314473e1165f6b58018e20ed8f07b840"
+	EscrowFactoryAddress    = "0xa7bcb4eac8964306f9e3764f67db6a7af6ddf99a"
+	WETHAddress             = "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2"
+	USDCAddress             = "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"
+	DefaultQuoteID          = "fakeapi-quote-id"
+	DefaultOrderHash        = "0x6b3c6b5a1b9e4c36d2f5c30e0b1b9c3f7d2a1e0f9c8b7a6d5e4f3c2b1a09f8e7"
+)
+
+// Fixture is a canned response.
+type Fixture struct {
+	StatusCode int
+	// Body is sent as is; it is usually JSON
+	Body string
+}
+
+// DefaultFixtures returns the responses the server starts with: small but realistic payloads
+// that the SDK clients decode successfully, so that whole flows such as quoting and placing a
+// fusion order work without any setup.
+func DefaultFixtures() map[Endpoint]Fixture {
+	ok := func(body string) Fixture {
+		return Fixture{StatusCode: http.StatusOK, Body: body}
+	}
+	return map[Endpoint]Fixture{
+		SwapQuote: ok(`{
+			"srcToken": {"address": "` + WETHAddress + `", "symbol": "WETH", "name": "Wrapped Ether", "decimals": 18, "logoURI": ""},
+			"dstToken": {"address": "` + USDCAddress + `", "symbol": "USDC", "name": "USD Coin", "decimals": 6, "logoURI": ""},
+			"dstAmount": "3500000000",
+			"gas": 180000
+		}`),
+		SwapSwap: ok(`{
+			"dstAmount": "3500000000",
+			"tx": {
+				"from": "0x0000000000000000000000000000000000000001",
+				"to": "` + RouterAddress + `",
+				"data": "0x07ed2379",
+				"value": "0",
+				"gas": 180000,
+				"gasPrice": "20000000000"
+			}
+		}`),
+		SwapApproveSpender: ok(`{"address": "` + RouterAddress + `"}`),
+		SwapApproveTransaction: ok(`{
+			"data": "0x095ea7b3000000000000000000000000111111125421ca6dc452d289314280a0f8842a65ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+			"gasPrice": "20000000000",
+			"to": "` + USDCAddress + `",
+			"value": "0"
+		}`),
+		SwapApproveAllowance: ok(`{"allowance": "0"}`),
+		SwapTokens: ok(`{"tokens": {
+			"` + WETHAddress + `": {"address": "` + WETHAddress + `", "symbol": "WETH", "name": "Wrapped Ether", "decimals": 18, "logoURI": ""},
+			"` + USDCAddress + `": {"address": "` + USDCAddress + `", "symbol": "USDC", "name": "USD Coin", "decimals": 6, "logoURI": ""}
+		}}`),
+		SwapLiquiditySources: ok(`{"protocols": [{"id": "UNISWAP_V3", "title": "Uniswap V3", "img": "", "img_color": ""}]}`),
+
+		FusionQuote:             ok(fusionQuote),
+		FusionQuoteCustomPreset: ok(fusionQuote),
+		FusionSubmitOrder:       {StatusCode: http.StatusCreated},
+		FusionSubmitOrders:      {StatusCode: http.StatusCreated},
+		FusionActiveOrders:      ok(`{"meta": {"totalItems": 0, "itemsPerPage": 100, "totalPages": 0, "currentPage": 1}, "items": []}`),
+		FusionOrderStatus: ok(`{
+			"orderHash": "` + DefaultOrderHash + `",
+			"status": "pending",
+			"order": {"maker": "0x0000000000000000000000000000000000000001", "makerAsset": "` + WETHAddress + `", "takerAsset": "` + USDCAddress + `", "makerTraits": "0", "salt": "1", "makingAmount": "1000000000000000000", "takingAmount": "3400000000", "receiver": "0x0000000000000000000000000000000000000000"},
+			"extension": "0x",
+			"points": [],
+			"fills": [],
+			"auctionStartDate": 1700000000,
+			"auctionDuration": 180,
+			"initialRateBump": 50000,
+			"createdAt": "2026-01-01T00:00:00.000Z",
+			"fromTokenToUsdPrice": "3500",
+			"toTokenToUsdPrice": "1",
+			"cancelTx": null,
+			"isNativeCurrency": false
+		}`),
+		FusionSettlement: ok(`{"address": "` + FusionSettlementAddress + `"}`),
+
+		FusionPlusQuote:        ok(fusionPlusQuote),
+		FusionPlusSubmitOrder:  {StatusCode: http.StatusCreated},
+		FusionPlusSubmitSecret: {StatusCode: http.StatusCreated},
+		FusionPlusActiveOrders: ok(`{"meta": {"totalItems": 0, "itemsPerPage": 100, "totalPages": 0, "currentPage": 1}, "items": []}`),
+		FusionPlusOrderStatus: ok(`{
+			"orderHash": "` + DefaultOrderHash + `",
+			"status": "pending",
+			"validation": "valid",
+			"srcChainId": 1,
+			"dstChainId": 42161,
+			"fills": [],
+			"cancelable": true
+		}`),
+		FusionPlusReadyToAcceptFills: ok(`{"fills": []}`),
+		FusionPlusEscrowFactory:      ok(`{"address": "` + EscrowFactoryAddress + `"}`),
+
+		OrderbookCreateOrder:     {StatusCode: http.StatusCreated, Body: `{"success": true}`},
+		OrderbookOrdersByCreator: ok(`[]`),
+		OrderbookAllOrders:       ok(`{"meta": {"hasMore": false, "nextCursor": "", "count": 0}, "items": []}`),
+		OrderbookOrderCount:      ok(`{"count": 0}`),
+		OrderbookFeeInfo: ok(`{
+			"whitelist": {},
+			"feeBps": 0,
+			"whitelistDiscountPercent": 0,
+			"protocolFeeReceiver": "0x0000000000000000000000000000000000000000",
+			"extensionAddress": "0x0000000000000000000000000000000000000000"
+		}`),
+
+		BalancesByWallet:                   ok(`{"` + WETHAddress + `": "1000000000000000000", "` + USDCAddress + `": "0"}`),
+		BalancesOfCustomTokens:             ok(`{"` + WETHAddress + `": "1000000000000000000"}`),
+		BalancesOfCustomTokensByWallets:    ok(`{}`),
+		BalancesAndAllowances:              ok(`{"` + WETHAddress + `": {"balance": "1000000000000000000", "allowance": "0"}}`),
+		BalancesAndAllowancesOfCustomToken: ok(`{"` + WETHAddress + `": {"balance": "1000000000000000000", "allowance": "0"}}`),
+		BalancesAndAllowancesAggregated:    ok(`[]`),
+		AllowancesByWallet:                 ok(`{"` + WETHAddress + `": "0"}`),
+		AllowancesOfCustomTokens:           ok(`{"` + WETHAddress + `": "0"}`),
+
+		SpotPricesWhitelisted:    ok(`{"` + WETHAddress + `": "3500", "` + USDCAddress + `": "1"}`),
+		SpotPricesCurrencies:     ok(`{"codes": ["USD", "EUR"]}`),
+		SpotPricesRequested:      ok(`{"` + WETHAddress + `": "3500"}`),
+		SpotPricesRequestedLarge: ok(`{"` + WETHAddress + `": "3500"}`),
+
+		TokensSearchAllChains: ok(`[` + wethToken + `]`),
+		TokensSearch:          ok(`[` + wethToken + `]`),
+		TokensWhitelisted:     ok(`{"` + WETHAddress + `": ` + wethToken + `}`),
+		TokensWhitelistedList: ok(`{
+			"name": "1inch",
+			"logoURI": "",
+			"keywords": [],
+			"tags": {},
+			"tags_order": [],
+			"timestamp": "2026-01-01T00:00:00.000Z",
+			"version": {"major": 1, "minor": 0, "patch": 0},
+			"tokens": [{"chainId": 1, "address": "` + WETHAddress + `", "name": "Wrapped Ether", "symbol": "WETH", "decimals": 18, "logoURI": "", "extensions": {}, "tags": [], "providers": []}]
+		}`),
+		TokensCustom:      ok(`{"` + WETHAddress + `": ` + wethToken + `}`),
+		TokensCustomToken: ok(wethToken),
+
+		GasPriceLegacy: ok(`{"standard": "3000000000", "fast": "3500000000", "instant": "5000000000"}`),
+		GasPrice: ok(`{
+			"baseFee": "10000000000",
+			"low": {"maxPriorityFeePerGas": "1000000000", "maxFeePerGas": "21000000000"},
+			"medium": {"maxPriorityFeePerGas": "1500000000", "maxFeePerGas": "21500000000"},
+			"high": {"maxPriorityFeePerGas": "2000000000", "maxFeePerGas": "22000000000"},
+			"instant": {"maxPriorityFeePerGas": "3000000000", "maxFeePerGas": "33000000000"}
+		}`),
+	}
+}
+
+const wethToken = `{"chainId": 1, "address": "` + WETHAddress + `", "name": "Wrapped Ether", "symbol": "WETH", "decimals": 18, "providers": ["1inch"], "tags": []}`
+
+const fusionPreset = `{
+	"auctionDuration": 180,
+	"startAuctionIn": 12,
+	"initialRateBump": 50000,
+	"auctionStartAmount": "3500000000",
+	"startAmount": "1000000000000000000",
+	"auctionEndAmount": "3400000000",
+	"exclusiveResolver": null,
+	"tokenFee": "0",
+	"estP": 0.95,
+	"points": [{"delay": 12, "coefficient": 20000}, {"delay": 24, "coefficient": 10000}],
+	"allowPartialFills": true,
+	"allowMultipleFills": true,
+	"gasCost": {"gasBumpEstimate": 10000, "gasPriceEstimate": "1000"}
+}`
+
+const fusionQuote = `{
+	"quoteId": "` + DefaultQuoteID + `",
+	"fromTokenAmount": "1000000000000000000",
+	"toTokenAmount": "3500000000",
+	"feeToken": "` + WETHAddress + `",
+	"presets": {"fast": ` + fusionPreset + `, "medium": ` + fusionPreset + `, "slow": ` + fusionPreset + `},
+	"recommended_preset": "fast",
+	"settlementAddress": "` + FusionSettlementAddress + `",
+	"whitelist": ["0x00000000219ab540356cbb839cbe05303d7705fa"],
+	"prices": {"usd": {"fromToken": "3500", "toToken": "1"}},
+	"volume": {"usd": {"fromToken": "3500", "toToken": "3500"}},
+	"suggested": true,
+	"surplusFee": 0,
+	"marketAmount": "3500000000"
+}`
+
+const fusionPlusPreset = `{
+	"auctionDuration": 180,
+	"startAuctionIn": 12,
+	"initialRateBump": 50000,
+	"auctionStartAmount": "3500000000",
+	"startAmount": "1000000000000000000",
+	"auctionEndAmount": "3400000000",
+	"costInDstToken": "0",
+	"exclusiveResolver": null,
+	"points": [{"delay": 12, "coefficient": 20000}],
+	"allowPartialFills": false,
+	"allowMultipleFills": false,
+	"gasCost": {"gasBumpEstimate": 10000, "gasPriceEstimate": "1000"},
+	"secretsCount": 1
+}`
+
+const fusionPlusQuote = `{
+	"quoteId": "` + DefaultQuoteID + `",
+	"srcTokenAmount": "1000000000000000000",
+	"dstTokenAmount": "3500000000",
+	"presets": {"fast": ` + fusionPlusPreset + `, "medium": ` + fusionPlusPreset + `, "slow": ` + fusionPlusPreset + `},
+	"recommendedPreset": "fast",
+	"srcEscrowFactory": "` + EscrowFactoryAddress + `",
+	"dstEscrowFactory": "` + EscrowFactoryAddress + `",
+	"srcSafetyDeposit": "1000000000000000",
+	"dstSafetyDeposit": "1000000000000000",
+	"whitelist": ["0x00000000219ab540356cbb839cbe05303d7705fa"],
+	"timeLocks": {
+		"srcWithdrawal": 36,
+		"srcPublicWithdrawal": 372,
+		"srcCancellation": 528,
+		"srcPublicCancellation": 648,
+		"dstWithdrawal": 36,
+		"dstPublicWithdrawal": 336,
+		"dstCancellation": 456
+	},
+	"prices": {"usd": {"srcToken": "3500", "dstToken": "1"}},
+	"volume": {"usd": {"srcToken": "3500", "dstToken": "3500"}}
+}`
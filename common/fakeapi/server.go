@@ -0,0 +1,303 @@
+// Package fakeapi provides an in-process fake of the 1inch API for integration tests of code
+// built on the SDK clients. The server answers every endpoint the SDK calls with realistic
+// default fixtures, lets tests replace responses and inject failures per endpoint, and captures
+// every request so tests can assert on what was sent:
+//
+//	server := fakeapi.NewServer()
+//	defer server.Close()
+//
+//	config, err := fusion.NewConfiguration(fusion.ConfigurationParams{
+//		ApiUrl:     server.URL,
+//		ApiKey:     "test",
+//		ChainId:    constants.EthereumChainId,
+//		PrivateKey: privateKey,
+//	})
+//	...
+//	server.InjectFault(fakeapi.FusionQuote, fakeapi.TooManyRequests(0))
+//	orderHash, err := client.PlaceOrderFromParams(ctx, params)
+//	...
+//	var order fusion.SignedOrderInput
+//	err = server.SubmittedOrders()[0].DecodeBody(&order)
+//
+// Requests to routes the server does not know are answered with 404 Not Found.
+package fakeapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Server is a running fake 1inch API. It is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	fixtures map[Endpoint]Fixture
+	handlers map[Endpoint]http.HandlerFunc
+	faults   map[Endpoint][]Fault
+	requests []Request
+}
+
+// NewServer starts a fake API with the default fixtures. Call Close when done.
+func NewServer() *Server {
+	s := &Server{
+		fixtures: DefaultFixtures(),
+		handlers: make(map[Endpoint]http.HandlerFunc),
+		faults:   make(map[Endpoint][]Fault),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Request is a request received by the server.
+type Request struct {
+	// Endpoint is the route the request matched; it is empty for unknown routes
+	Endpoint Endpoint
+	Method   string
+	Path     string
+	Query    url.Values
+	Header   http.Header
+	Body     []byte
+}
+
+// DecodeBody decodes the JSON request body into v.
+func (r Request) DecodeBody(v any) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+// Fault is a failure response injected ahead of an endpoint's normal response.
+type Fault struct {
+	StatusCode int
+	Body       string
+	Header     http.Header
+	// Times is the number of requests that fail. Zero means every request until ClearFaults.
+	Times int
+}
+
+// TooManyRequests is a 429 response carrying a Retry-After header of retryAfter, rounded
+// down to whole seconds, as the 1inch API sends when the rate limit is exceeded.
+func TooManyRequests(retryAfter time.Duration) Fault {
+	return Fault{
+		StatusCode: http.StatusTooManyRequests,
+		Body:       `{"statusCode":429,"error":"Too Many Requests","description":"Too Many Requests"}`,
+		Header:     http.Header{"Retry-After": {strconv.Itoa(int(retryAfter.Seconds()))}},
+		Times:      1,
+	}
+}
+
+// InternalServerError is a single 500 response.
+func InternalServerError() Fault {
+	return Fault{
+		StatusCode: http.StatusInternalServerError,
+		Body:       `{"statusCode":500,"error":"Internal Server Error","description":"Internal server error"}`,
+		Times:      1,
+	}
+}
+
+// ValidationError is a 400 response in the 1inch error format with the given description,
+// and an optional parameter name reported in meta.
+func ValidationError(description string, parameter string) Fault {
+	body := map[string]any{
+		"statusCode":  http.StatusBadRequest,
+		"error":       "Bad Request",
+		"description": description,
+		"requestId":   "fakeapi-request-id",
+	}
+	if parameter != "" {
+		body["meta"] = []map[string]string{{"type": "parameter", "value": parameter}}
+	}
+	encoded, _ := json.Marshal(body)
+	return Fault{
+		StatusCode: http.StatusBadRequest,
+		Body:       string(encoded),
+		Times:      1,
+	}
+}
+
+// SetResponse replaces the fixture of endpoint. body is sent as is when it is a string or
+// []byte, and encoded as JSON otherwise.
+func (s *Server) SetResponse(endpoint Endpoint, statusCode int, body any) error {
+	var raw string
+	switch b := body.(type) {
+	case string:
+		raw = b
+	case []byte:
+		raw = string(b)
+	default:
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode fixture for %s: %w", endpoint, err)
+		}
+		raw = string(encoded)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fixtures[endpoint] = Fixture{StatusCode: statusCode, Body: raw}
+	return nil
+}
+
+// Handle serves endpoint with handler instead of its fixture, for responses that depend
+// on the request. Requests are still captured and faults still apply.
+func (s *Server) Handle(endpoint Endpoint, handler http.HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[endpoint] = handler
+}
+
+// InjectFault queues fault for endpoint. Queued faults are served in order before the
+// endpoint responds normally again.
+func (s *Server) InjectFault(endpoint Endpoint, fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults[endpoint] = append(s.faults[endpoint], fault)
+}
+
+// ClearFaults removes every queued fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = make(map[Endpoint][]Fault)
+}
+
+// Requests returns the captured requests that matched endpoint, in arrival order.
+func (s *Server) Requests(endpoint Endpoint) []Request {
+	return s.filter(endpoint)
+}
+
+// AllRequests returns every captured request, in arrival order.
+func (s *Server) AllRequests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// SubmittedOrders returns the requests that submitted fusion, fusion plus and orderbook
+// orders, in arrival order, including those answered with an injected fault.
+func (s *Server) SubmittedOrders() []Request {
+	return s.filter(orderSubmissionEndpoints...)
+}
+
+func (s *Server) filter(endpoints ...Endpoint) []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var matched []Request
+	for _, r := range s.requests {
+		for _, e := range endpoints {
+			if r.Endpoint == e {
+				matched = append(matched, r)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	endpoint, found := match(r.Method, r.URL.Path)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Endpoint: endpoint,
+		Method:   r.Method,
+		Path:     r.URL.Path,
+		Query:    r.URL.Query(),
+		Header:   r.Header.Clone(),
+		Body:     body,
+	})
+	fault, faulted := s.nextFault(endpoint)
+	handler := s.handlers[endpoint]
+	fixture, hasFixture := s.fixtures[endpoint]
+	s.mu.Unlock()
+
+	switch {
+	case !found:
+		writeResponse(w, http.StatusNotFound, nil, fmt.Sprintf(`{"statusCode":404,"error":"Not Found","description":"fakeapi: no route for %s %s"}`, r.Method, r.URL.Path))
+	case faulted:
+		writeResponse(w, fault.StatusCode, fault.Header, fault.Body)
+	case handler != nil:
+		handler(w, r)
+	case hasFixture:
+		writeResponse(w, fixture.StatusCode, nil, fixture.Body)
+	default:
+		writeResponse(w, http.StatusNotImplemented, nil, fmt.Sprintf(`{"statusCode":501,"error":"Not Implemented","description":"fakeapi: no fixture for %s"}`, endpoint))
+	}
+}
+
+// nextFault pops the next fault queued for endpoint. Callers must hold s.mu.
+func (s *Server) nextFault(endpoint Endpoint) (Fault, bool) {
+	queue := s.faults[endpoint]
+	if len(queue) == 0 {
+		return Fault{}, false
+	}
+	fault := queue[0]
+	if fault.Times == 0 {
+		return fault, true
+	}
+	if fault.Times == 1 {
+		s.faults[endpoint] = queue[1:]
+	} else {
+		queue[0].Times--
+	}
+	return fault, true
+}
+
+// match returns the first route matching method and urlPath.
+func match(method string, urlPath string) (Endpoint, bool) {
+	for _, e := range routes {
+		if e.Method != method {
+			continue
+		}
+		if matchPattern(e.Pattern, urlPath) {
+			return e, true
+		}
+	}
+	return Endpoint{}, false
+}
+
+// matchPattern reports whether urlPath matches pattern, trying every alternative of the first
+// "{a,b}" group in turn
+func matchPattern(pattern string, urlPath string) bool {
+	start := strings.Index(pattern, "{")
+	end := strings.Index(pattern, "}")
+	if start < 0 || end < start {
+		ok, _ := path.Match(pattern, urlPath)
+		return ok
+	}
+	for _, alternative := range strings.Split(pattern[start+1:end], ",") {
+		if matchPattern(pattern[:start]+alternative+pattern[end+1:], urlPath) {
+			return true
+		}
+	}
+	return false
+}
+
+func writeResponse(w http.ResponseWriter, statusCode int, header http.Header, body string) {
+	for key, values := range header {
+		for _, v := range values {
+			w.Header().Add(key, v)
+		}
+	}
+	if body != "" && w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	w.WriteHeader(statusCode)
+	_, _ = io.WriteString(w, body)
+}
@@ -0,0 +1,360 @@
+package fakeapi_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+	"github.com/1inch/1inch-sdk-go/v4/common/fakeapi"
+	"github.com/1inch/1inch-sdk-go/v4/constants"
+	"github.com/1inch/1inch-sdk-go/v4/sdk-clients/aggregation"
+	"github.com/1inch/1inch-sdk-go/v4/sdk-clients/balances"
+	"github.com/1inch/1inch-sdk-go/v4/sdk-clients/fusion"
+	"github.com/1inch/1inch-sdk-go/v4/sdk-clients/fusionplus"
+	"github.com/1inch/1inch-sdk-go/v4/sdk-clients/gasprices"
+	"github.com/1inch/1inch-sdk-go/v4/sdk-clients/orderbook"
+	"github.com/1inch/1inch-sdk-go/v4/sdk-clients/spotprices"
+	"github.com/1inch/1inch-sdk-go/v4/sdk-clients/tokens"
+)
+
+const (
+	testPrivateKey = "965e092fdfc08940d2bd05c7b5c7e1c51e283e92c7f52bbf1408973ae9a9acb7"
+	testWallet     = "0x2c9b2DBdbA8A9c969Ac24153f5C1c23CB0e63914"
+)
+
+func TestDefaultFixturesDecode(t *testing.T) {
+	server := fakeapi.NewServer()
+	defer server.Close()
+	ctx := context.Background()
+
+	aggregationConfig, err := aggregation.NewConfigurationAPI(constants.EthereumChainId, server.URL, "test")
+	require.NoError(t, err)
+	aggregationClient, err := aggregation.NewClientOnlyAPI(aggregationConfig)
+	require.NoError(t, err)
+
+	fusionConfig, err := fusion.NewConfiguration(fusion.ConfigurationParams{ChainId: constants.EthereumChainId, ApiUrl: server.URL, ApiKey: "test", PrivateKey: testPrivateKey})
+	require.NoError(t, err)
+	fusionClient, err := fusion.NewClient(fusionConfig)
+	require.NoError(t, err)
+
+	fusionPlusConfig, err := fusionplus.NewConfiguration(fusionplus.ConfigurationParams{ApiUrl: server.URL, ApiKey: "test", PrivateKey: testPrivateKey})
+	require.NoError(t, err)
+	fusionPlusClient, err := fusionplus.NewClient(fusionPlusConfig)
+	require.NoError(t, err)
+
+	orderbookConfig, err := orderbook.NewConfigurationAPI(constants.EthereumChainId, server.URL, "test")
+	require.NoError(t, err)
+	orderbookClient, err := orderbook.NewClientOnlyAPI(orderbookConfig)
+	require.NoError(t, err)
+
+	balancesConfig, err := balances.NewConfiguration(balances.ConfigurationParams{ChainId: constants.EthereumChainId, ApiUrl: server.URL, ApiKey: "test"})
+	require.NoError(t, err)
+	balancesClient, err := balances.NewClient(balancesConfig)
+	require.NoError(t, err)
+
+	spotpricesConfig, err := spotprices.NewConfiguration(spotprices.ConfigurationParams{ChainId: constants.EthereumChainId, ApiUrl: server.URL, ApiKey: "test"})
+	require.NoError(t, err)
+	spotpricesClient, err := spotprices.NewClient(spotpricesConfig)
+	require.NoError(t, err)
+
+	tokensConfig, err := tokens.NewConfiguration(tokens.ConfigurationParams{ChainId: constants.EthereumChainId, ApiUrl: server.URL, ApiKey: "test"})
+	require.NoError(t, err)
+	tokensClient, err := tokens.NewClient(tokensConfig)
+	require.NoError(t, err)
+
+	gaspricesConfig, err := gasprices.NewConfiguration(gasprices.ConfigurationParams{ChainId: constants.EthereumChainId, ApiUrl: server.URL, ApiKey: "test"})
+	require.NoError(t, err)
+	gaspricesClient, err := gasprices.NewClient(gaspricesConfig)
+	require.NoError(t, err)
+
+	bscGaspricesConfig, err := gasprices.NewConfiguration(gasprices.ConfigurationParams{ChainId: constants.BscChainId, ApiUrl: server.URL, ApiKey: "test"})
+	require.NoError(t, err)
+	bscGaspricesClient, err := gasprices.NewClient(bscGaspricesConfig)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		endpoint fakeapi.Endpoint
+		call     func() error
+	}{
+		{
+			name:     "Aggregation quote",
+			endpoint: fakeapi.SwapQuote,
+			call: func() error {
+				quote, err := aggregationClient.GetQuote(ctx, aggregation.GetQuoteParams{Src: fakeapi.WETHAddress, Dst: fakeapi.USDCAddress, Amount: "1000000000000000000"})
+				if err == nil {
+					assert.Equal(t, "3500000000", quote.DstAmount)
+				}
+				return err
+			},
+		},
+		{
+			name:     "Aggregation approve spender",
+			endpoint: fakeapi.SwapApproveSpender,
+			call: func() error {
+				spender, err := aggregationClient.GetApproveSpender(ctx)
+				if err == nil {
+					assert.Equal(t, fakeapi.RouterAddress, spender.Address)
+				}
+				return err
+			},
+		},
+		{
+			name:     "Aggregation tokens",
+			endpoint: fakeapi.SwapTokens,
+			call: func() error {
+				_, err := aggregationClient.GetTokens(ctx)
+				return err
+			},
+		},
+		{
+			name:     "Fusion settlement contract",
+			endpoint: fakeapi.FusionSettlement,
+			call: func() error {
+				settlement, err := fusionClient.GetSettlementContract(ctx)
+				if err == nil {
+					assert.Equal(t, fakeapi.FusionSettlementAddress, settlement.Address)
+				}
+				return err
+			},
+		},
+		{
+			name:     "Fusion order status",
+			endpoint: fakeapi.FusionOrderStatus,
+			call: func() error {
+				status, err := fusionClient.GetOrderStatus(ctx, fakeapi.DefaultOrderHash)
+				if err == nil {
+					assert.Equal(t, "pending", status.Status)
+				}
+				return err
+			},
+		},
+		{
+			name:     "Fusion plus quote",
+			endpoint: fakeapi.FusionPlusQuote,
+			call: func() error {
+				quote, err := fusionPlusClient.GetQuote(ctx, fusionplus.QuoterControllerGetQuoteParamsFixed{
+					SrcChain:        constants.EthereumChainId,
+					DstChain:        constants.ArbitrumChainId,
+					SrcTokenAddress: fakeapi.WETHAddress,
+					DstTokenAddress: "0xaf88d065e77c8cc2239327c5edb3a432268e5831",
+					Amount:          "1000000000000000000",
+					WalletAddress:   testWallet,
+					EnableEstimate:  true,
+				})
+				if err == nil {
+					assert.Equal(t, fakeapi.DefaultQuoteID, quote.QuoteId)
+				}
+				return err
+			},
+		},
+		{
+			name:     "Orderbook fee info",
+			endpoint: fakeapi.OrderbookFeeInfo,
+			call: func() error {
+				_, err := orderbookClient.GetFeeInfo(ctx, orderbook.GetFeeInfoParams{MakerAsset: fakeapi.WETHAddress, TakerAsset: fakeapi.USDCAddress, MakerAmount: "1", TakerAmount: "1"})
+				return err
+			},
+		},
+		{
+			name:     "Balances by wallet",
+			endpoint: fakeapi.BalancesByWallet,
+			call: func() error {
+				balances, err := balancesClient.GetBalancesByWalletAddress(ctx, balances.BalancesByWalletAddressParams{Wallet: testWallet})
+				if err == nil {
+					assert.Equal(t, "1000000000000000000", (*balances)[fakeapi.WETHAddress])
+				}
+				return err
+			},
+		},
+		{
+			name:     "Spot price currencies",
+			endpoint: fakeapi.SpotPricesCurrencies,
+			call: func() error {
+				_, err := spotpricesClient.GetCustomCurrenciesList(ctx)
+				return err
+			},
+		},
+		{
+			name:     "Whitelisted tokens",
+			endpoint: fakeapi.TokensWhitelisted,
+			call: func() error {
+				_, err := tokensClient.WhitelistedTokens(ctx, tokens.TokenListControllerTokensParams{})
+				return err
+			},
+		},
+		{
+			name:     "Gas price",
+			endpoint: fakeapi.GasPrice,
+			call: func() error {
+				gasPrice, err := gaspricesClient.GetGasPriceEIP1559(ctx)
+				if err == nil {
+					assert.Equal(t, "10000000000", gasPrice.BaseFee)
+				}
+				return err
+			},
+		},
+		{
+			name:     "Legacy gas price",
+			endpoint: fakeapi.GasPriceLegacy,
+			call: func() error {
+				gasPrice, err := bscGaspricesClient.GetGasPriceLegacy(ctx)
+				if err == nil {
+					assert.Equal(t, "3500000000", gasPrice.Fast)
+				}
+				return err
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, tc.call())
+			assert.NotEmpty(t, server.Requests(tc.endpoint))
+		})
+	}
+}
+
+func TestPlaceOrderCapture(t *testing.T) {
+	server := fakeapi.NewServer()
+	defer server.Close()
+
+	config, err := fusion.NewConfiguration(fusion.ConfigurationParams{
+		ChainId:    constants.EthereumChainId,
+		ApiUrl:     server.URL,
+		ApiKey:     "test",
+		PrivateKey: testPrivateKey,
+	})
+	require.NoError(t, err)
+	client, err := fusion.NewClient(config)
+	require.NoError(t, err)
+
+	orderHash, err := client.PlaceOrderFromParams(context.Background(), fusion.OrderParams{
+		WalletAddress:    testWallet,
+		FromTokenAddress: fakeapi.WETHAddress,
+		ToTokenAddress:   fakeapi.USDCAddress,
+		Amount:           "1000000000000000000",
+		Receiver:         constants.ZeroAddress,
+		Preset:           fusion.Fast,
+	})
+	require.NoError(t, err)
+
+	quoteRequests := server.Requests(fakeapi.FusionQuote)
+	require.Len(t, quoteRequests, 1)
+	assert.Equal(t, "Bearer test", quoteRequests[0].Header.Get("Authorization"))
+	assert.Equal(t, "1000000000000000000", quoteRequests[0].Query.Get("amount"))
+
+	submitted := server.SubmittedOrders()
+	require.Len(t, submitted, 1)
+	var order fusion.SignedOrderInput
+	require.NoError(t, submitted[0].DecodeBody(&order))
+	assert.Equal(t, fakeapi.DefaultQuoteID, order.QuoteId)
+	assert.Equal(t, "1000000000000000000", order.Order.MakingAmount)
+	assert.Equal(t, fakeapi.WETHAddress, order.Order.MakerAsset)
+	assert.NotEmpty(t, order.Signature)
+	assert.NotEmpty(t, orderHash)
+}
+
+func TestFaults(t *testing.T) {
+	quoteParams := aggregation.GetQuoteParams{Src: fakeapi.WETHAddress, Dst: fakeapi.USDCAddress, Amount: "1"}
+
+	tests := []struct {
+		name             string
+		faults           []fakeapi.Fault
+		opts             []common.HttpOption
+		expectedErr      error
+		expectedStatus   int
+		expectedRequests int
+	}{
+		{
+			name:             "Rate limit surfaces as ErrRateLimited",
+			faults:           []fakeapi.Fault{fakeapi.TooManyRequests(time.Second)},
+			expectedErr:      common.ErrRateLimited,
+			expectedStatus:   http.StatusTooManyRequests,
+			expectedRequests: 1,
+		},
+		{
+			name:             "Retries recover from injected failures",
+			faults:           []fakeapi.Fault{fakeapi.TooManyRequests(0), fakeapi.InternalServerError()},
+			opts:             []common.HttpOption{common.WithRetryPolicy(common.RetryPolicy{MaxAttempts: 3})},
+			expectedRequests: 3,
+		},
+		{
+			name:             "Validation error",
+			faults:           []fakeapi.Fault{fakeapi.ValidationError("insufficient liquidity", "amount")},
+			expectedErr:      common.ErrInsufficientLiquidity,
+			expectedStatus:   http.StatusBadRequest,
+			expectedRequests: 1,
+		},
+		{
+			name:             "Persistent fault",
+			faults:           []fakeapi.Fault{{StatusCode: http.StatusBadGateway, Times: 0}},
+			opts:             []common.HttpOption{common.WithRetryPolicy(common.RetryPolicy{MaxAttempts: 2})},
+			expectedStatus:   http.StatusBadGateway,
+			expectedRequests: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := fakeapi.NewServer()
+			defer server.Close()
+			for _, f := range tc.faults {
+				server.InjectFault(fakeapi.SwapQuote, f)
+			}
+
+			config, err := aggregation.NewConfigurationAPI(constants.EthereumChainId, server.URL, "test", tc.opts...)
+			require.NoError(t, err)
+			client, err := aggregation.NewClientOnlyAPI(config)
+			require.NoError(t, err)
+
+			_, err = client.GetQuote(context.Background(), quoteParams)
+			switch {
+			case tc.expectedErr != nil:
+				require.ErrorIs(t, err, tc.expectedErr)
+			case tc.expectedStatus == 0:
+				require.NoError(t, err)
+			}
+			if tc.expectedStatus != 0 {
+				var apiErr *common.APIError
+				require.ErrorAs(t, err, &apiErr)
+				assert.Equal(t, tc.expectedStatus, apiErr.StatusCode)
+			}
+			assert.Len(t, server.Requests(fakeapi.SwapQuote), tc.expectedRequests)
+		})
+	}
+}
+
+func TestServerResponses(t *testing.T) {
+	server := fakeapi.NewServer()
+	defer server.Close()
+
+	config, err := aggregation.NewConfigurationAPI(constants.EthereumChainId, server.URL, "test")
+	require.NoError(t, err)
+	client, err := aggregation.NewClientOnlyAPI(config)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, server.SetResponse(fakeapi.SwapApproveAllowance, http.StatusOK, aggregation.AllowanceResponse{Allowance: "42"}))
+	allowance, err := client.GetApproveAllowance(ctx, aggregation.GetAllowanceParams{TokenAddress: fakeapi.USDCAddress, WalletAddress: testWallet})
+	require.NoError(t, err)
+	assert.Equal(t, "42", allowance.Allowance)
+
+	server.Handle(fakeapi.SwapQuote, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"dstAmount":"` + r.URL.Query().Get("amount") + `"}`))
+	})
+	quote, err := client.GetQuote(ctx, aggregation.GetQuoteParams{Src: fakeapi.WETHAddress, Dst: fakeapi.USDCAddress, Amount: "7"})
+	require.NoError(t, err)
+	assert.Equal(t, "7", quote.DstAmount)
+
+	resp, err := http.Get(server.URL + "/unknown/route")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Len(t, server.AllRequests(), 3)
+}
@@ -0,0 +1,35 @@
+package common
+
+import (
+	"context"
+	"math/big"
+)
+
+// FeeStrategy chooses the fees of the transactions a transaction builder builds. Fees set
+// explicitly on the builder take precedence. The common/fees package provides strategies
+// based on node suggestions, fixed values and a fee ceiling, and gasprices.NewFeeStrategy
+// one based on the tiers of the 1inch gas price API.
+type FeeStrategy interface {
+	// DynamicFees returns the priority fee and fee cap of an EIP-1559 transaction.
+	DynamicFees(ctx context.Context, wallet Wallet) (gasTipCap *big.Int, gasFeeCap *big.Int, err error)
+	// GasPrice returns the gas price of a legacy transaction.
+	GasPrice(ctx context.Context, wallet Wallet) (*big.Int, error)
+}
+
+// FeeCeiling is implemented by fee strategies whose fees are a hard limit, such as fees.MaxFee.
+// When only the priority fee is set explicitly and it exceeds the strategy's fee cap, the
+// transaction builder raises the fee cap to it, and then asks a FeeCeiling whether the raised
+// fees are still allowed.
+type FeeCeiling interface {
+	// CheckDynamicFees returns an error when an EIP-1559 transaction may not pay these fees.
+	CheckDynamicFees(gasTipCap *big.Int, gasFeeCap *big.Int) error
+}
+
+// WithFeeStrategy makes the transaction builder take fees from strategy. Without it the
+// builder uses the node's suggested priority fee and twice its suggested gas price as the
+// fee cap, or the suggested gas price for legacy transactions.
+func WithFeeStrategy(strategy FeeStrategy) WalletOption {
+	return func(cfg *WalletConfig) {
+		cfg.FeeStrategy = strategy
+	}
+}
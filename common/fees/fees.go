@@ -0,0 +1,143 @@
+// Package fees provides common.FeeStrategy implementations for the transaction builder:
+//
+//	gasPrices, err := gasprices.NewClient(gasPricesConfig)
+//	strategy := fees.MaxFee(gasprices.NewFeeStrategy(gasPrices, gasprices.TierHigh), big.NewInt(50e9))
+//	config, err := aggregation.NewConfiguration(aggregation.ConfigurationParams{
+//		...
+//		WalletOptions: []common.WalletOption{common.WithFeeStrategy(strategy)},
+//	})
+//
+// Swaps built with client.TxBuilder then pay the API's high tier, and building fails instead
+// of paying more than 50 gwei per gas.
+package fees
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+)
+
+// ErrAboveCeiling is returned by a MaxFee strategy when the fees exceed the ceiling.
+var ErrAboveCeiling = errors.New("fee exceeds the configured maximum")
+
+var (
+	_ common.FeeStrategy = nodeStrategy{}
+	_ common.FeeStrategy = fixedStrategy{}
+	_ common.FeeStrategy = maxFeeStrategy{}
+	_ common.FeeCeiling  = maxFeeStrategy{}
+)
+
+type nodeStrategy struct{}
+
+// Node returns the strategy the transaction builder uses by default: the node's suggested
+// priority fee, and twice the node's suggested gas price as the fee cap.
+func Node() common.FeeStrategy {
+	return nodeStrategy{}
+}
+
+func (nodeStrategy) DynamicFees(ctx context.Context, wallet common.Wallet) (*big.Int, *big.Int, error) {
+	gasTipCap, err := wallet.GetGasTipCap(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	gasPrice, err := wallet.GetGasPrice(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The node's suggested gas price tracks the current base fee, so using it directly as
+	// the fee cap makes the transaction invalid as soon as the base fee rises before
+	// inclusion. Doubling the suggestion keeps the cap at or above twice the base fee plus
+	// the tip; the network only charges base fee plus tip, so the headroom adds no cost.
+	gasFeeCap := new(big.Int).Mul(gasPrice, big.NewInt(2))
+	if gasFeeCap.Cmp(gasTipCap) < 0 {
+		gasFeeCap = new(big.Int).Set(gasTipCap)
+	}
+	return gasTipCap, gasFeeCap, nil
+}
+
+func (nodeStrategy) GasPrice(ctx context.Context, wallet common.Wallet) (*big.Int, error) {
+	return wallet.GetGasPrice(ctx)
+}
+
+// FixedFees are the values a Fixed strategy returns.
+type FixedFees struct {
+	GasTipCap *big.Int
+	GasFeeCap *big.Int
+	GasPrice  *big.Int
+}
+
+type fixedStrategy struct {
+	fees FixedFees
+}
+
+// Fixed returns a strategy that always uses the given fees. Building a transaction type whose
+// fees are not set fails.
+func Fixed(fees FixedFees) common.FeeStrategy {
+	return fixedStrategy{fees: fees}
+}
+
+func (s fixedStrategy) DynamicFees(context.Context, common.Wallet) (*big.Int, *big.Int, error) {
+	if s.fees.GasTipCap == nil || s.fees.GasFeeCap == nil {
+		return nil, nil, errors.New("fixed fees have no priority fee and fee cap for dynamic transactions")
+	}
+	return new(big.Int).Set(s.fees.GasTipCap), new(big.Int).Set(s.fees.GasFeeCap), nil
+}
+
+func (s fixedStrategy) GasPrice(context.Context, common.Wallet) (*big.Int, error) {
+	if s.fees.GasPrice == nil {
+		return nil, errors.New("fixed fees have no gas price for legacy transactions")
+	}
+	return new(big.Int).Set(s.fees.GasPrice), nil
+}
+
+type maxFeeStrategy struct {
+	strategy     common.FeeStrategy
+	maxFeePerGas *big.Int
+}
+
+// MaxFee wraps strategy and refuses fees above maxFeePerGas: the priority fee and fee cap of
+// dynamic transactions, including a priority fee set explicitly on the builder, and the gas
+// price of legacy ones. Building then fails with ErrAboveCeiling instead of sending a
+// transaction that costs more than the budget.
+func MaxFee(strategy common.FeeStrategy, maxFeePerGas *big.Int) common.FeeStrategy {
+	return maxFeeStrategy{strategy: strategy, maxFeePerGas: maxFeePerGas}
+}
+
+func (s maxFeeStrategy) DynamicFees(ctx context.Context, wallet common.Wallet) (*big.Int, *big.Int, error) {
+	gasTipCap, gasFeeCap, err := s.strategy.DynamicFees(ctx, wallet)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.CheckDynamicFees(gasTipCap, gasFeeCap); err != nil {
+		return nil, nil, err
+	}
+	return gasTipCap, gasFeeCap, nil
+}
+
+func (s maxFeeStrategy) CheckDynamicFees(gasTipCap *big.Int, gasFeeCap *big.Int) error {
+	if err := s.check("gas tip cap", gasTipCap); err != nil {
+		return err
+	}
+	return s.check("fee cap", gasFeeCap)
+}
+
+func (s maxFeeStrategy) GasPrice(ctx context.Context, wallet common.Wallet) (*big.Int, error) {
+	gasPrice, err := s.strategy.GasPrice(ctx, wallet)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.check("gas price", gasPrice); err != nil {
+		return nil, err
+	}
+	return gasPrice, nil
+}
+
+func (s maxFeeStrategy) check(name string, fee *big.Int) error {
+	if fee.Cmp(s.maxFeePerGas) > 0 {
+		return fmt.Errorf("%w: %s %s > %s", ErrAboveCeiling, name, fee, s.maxFeePerGas)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package fees
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+)
+
+// nodeWallet suggests a tip of 2 gwei and a gas price of 30 gwei
+type nodeWallet struct {
+	common.Wallet
+}
+
+func (nodeWallet) GetGasTipCap(context.Context) (*big.Int, error) {
+	return big.NewInt(2e9), nil
+}
+
+func (nodeWallet) GetGasPrice(context.Context) (*big.Int, error) {
+	return big.NewInt(30e9), nil
+}
+
+func TestStrategies(t *testing.T) {
+	tests := []struct {
+		name              string
+		strategy          common.FeeStrategy
+		expectedGasTipCap *big.Int
+		expectedGasFeeCap *big.Int
+		expectedGasPrice  *big.Int
+		expectedError     string
+		expectedErrorIs   error
+	}{
+		{
+			name:              "Node",
+			strategy:          Node(),
+			expectedGasTipCap: big.NewInt(2e9),
+			expectedGasFeeCap: big.NewInt(60e9),
+			expectedGasPrice:  big.NewInt(30e9),
+		},
+		{
+			name:              "Fixed",
+			strategy:          Fixed(FixedFees{GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(9), GasPrice: big.NewInt(4)}),
+			expectedGasTipCap: big.NewInt(1),
+			expectedGasFeeCap: big.NewInt(9),
+			expectedGasPrice:  big.NewInt(4),
+		},
+		{
+			name:          "Fixed without dynamic fees",
+			strategy:      Fixed(FixedFees{GasPrice: big.NewInt(4)}),
+			expectedError: "fixed fees have no priority fee and fee cap for dynamic transactions",
+		},
+		{
+			name:              "Within the ceiling",
+			strategy:          MaxFee(Fixed(FixedFees{GasTipCap: big.NewInt(2e9), GasFeeCap: big.NewInt(40e9), GasPrice: big.NewInt(6e9)}), big.NewInt(40e9)),
+			expectedGasTipCap: big.NewInt(2e9),
+			expectedGasFeeCap: big.NewInt(40e9),
+			expectedGasPrice:  big.NewInt(6e9),
+		},
+		{
+			name:            "Above the ceiling",
+			strategy:        MaxFee(Node(), big.NewInt(50e9)),
+			expectedErrorIs: ErrAboveCeiling,
+			expectedError:   "fee exceeds the configured maximum: fee cap 60000000000 > 50000000000",
+		},
+		{
+			name:            "Tip above the ceiling",
+			strategy:        MaxFee(Fixed(FixedFees{GasTipCap: big.NewInt(60e9), GasFeeCap: big.NewInt(40e9), GasPrice: big.NewInt(4)}), big.NewInt(50e9)),
+			expectedErrorIs: ErrAboveCeiling,
+			expectedError:   "fee exceeds the configured maximum: gas tip cap 60000000000 > 50000000000",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			wallet := nodeWallet{}
+			gasTipCap, gasFeeCap, err := tc.strategy.DynamicFees(ctx, wallet)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				if tc.expectedErrorIs != nil {
+					require.ErrorIs(t, err, tc.expectedErrorIs)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedGasTipCap, gasTipCap)
+			assert.Equal(t, tc.expectedGasFeeCap, gasFeeCap)
+
+			gasPrice, err := tc.strategy.GasPrice(ctx, wallet)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedGasPrice, gasPrice)
+		})
+	}
+}
+
+func TestMaxFeeGasPrice(t *testing.T) {
+	_, err := MaxFee(Node(), big.NewInt(20e9)).GasPrice(context.Background(), nodeWallet{})
+	require.ErrorIs(t, err, ErrAboveCeiling)
+	require.EqualError(t, err, "fee exceeds the configured maximum: gas price 30000000000 > 20000000000")
+}
@@ -0,0 +1,231 @@
+package common
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// HttpOption customizes the HTTP executor that an SDK client's configuration builds.
+// Options are applied in order, so a later option overrides an earlier one.
+type HttpOption func(*HttpConfig)
+
+// HttpConfig holds the executor settings collected from HttpOption values.
+type HttpConfig struct {
+	// RetryPolicy controls automatic retries of failed requests. Nil disables retries.
+	RetryPolicy *RetryPolicy
+	// RateLimiter throttles outgoing requests. Nil disables client-side rate limiting.
+	RateLimiter RateLimiter
+	// Middleware wraps the transport of every request, outermost first.
+	Middleware []Middleware
+	// HttpClient is the client used to send requests. Nil means a copy of http.DefaultClient.
+	// Its Transport is wrapped by Middleware; the client itself is never modified.
+	HttpClient *http.Client
+	// Transport replaces the transport of HttpClient when set.
+	Transport http.RoundTripper
+	// Timeout overrides the per-attempt timeout of HttpClient when positive.
+	Timeout time.Duration
+	// Cache enables the response cache for GET requests. Nil disables caching.
+	Cache *CachePolicy
+	// Telemetry instruments requests. Nil uses the global OpenTelemetry providers.
+	Telemetry *Telemetry
+	// Logger receives debug logs of requests. Nil disables logging.
+	Logger *slog.Logger
+	// Executor replaces the SDK's HTTP executor entirely. All other settings are ignored when it is set.
+	Executor HttpExecutor
+}
+
+// NewHttpConfig applies opts to an empty HttpConfig.
+func NewHttpConfig(opts ...HttpOption) HttpConfig {
+	var cfg HttpConfig
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	return cfg
+}
+
+// RetryPolicy describes how the HTTP executor retries requests that fail with a
+// network error, a 429 Too Many Requests, or a 5xx server error.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// Values below 2 disable retries.
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry. It doubles on every
+	// following retry, with random jitter applied.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the computed backoff. A Retry-After header sent by the API
+	// takes precedence over the computed backoff.
+	MaxBackoff time.Duration
+	// MaxRetryAfter is the longest Retry-After the client waits for. A longer one ends the
+	// retries and the API error is returned right away. Zero means one minute.
+	MaxRetryAfter time.Duration
+	// RetryNonIdempotent allows retrying methods other than GET, HEAD and OPTIONS.
+	// Enable it only when resubmitting the request is safe, since a POST that timed
+	// out may already have been processed.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns a policy of 3 attempts with backoff starting at 500ms and
+// capped at 10s, retrying idempotent requests only.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+	}
+}
+
+// WithRetryPolicy enables automatic retries using policy.
+func WithRetryPolicy(policy RetryPolicy) HttpOption {
+	return func(cfg *HttpConfig) {
+		cfg.RetryPolicy = &policy
+	}
+}
+
+// RateLimiter throttles API requests on the client side. A single RateLimiter can be shared
+// by several SDK clients so that together they respect the limit of an API key.
+// See the common/ratelimit package for a token bucket implementation.
+type RateLimiter interface {
+	// Wait blocks until a request with apiKey to the URL path may be sent. It must return
+	// promptly with the context's error when ctx is done.
+	Wait(ctx context.Context, apiKey string, path string) error
+}
+
+// WithRateLimiter makes the executor wait on limiter before every request, including retries.
+func WithRateLimiter(limiter RateLimiter) HttpOption {
+	return func(cfg *HttpConfig) {
+		cfg.RateLimiter = limiter
+	}
+}
+
+// Middleware wraps the http.RoundTripper used to send API requests. It sees every attempt,
+// including retries, after the SDK has set its own headers, and sees the raw response before
+// it is decoded. It can be used for logging, metrics, custom headers, request signing or
+// fault injection. Per the http.RoundTripper contract, a middleware that changes the request
+// must clone it first:
+//
+//	tenantHeader := func(next http.RoundTripper) http.RoundTripper {
+//		return common.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+//			req = req.Clone(req.Context())
+//			req.Header.Set("X-Tenant-Id", "acme")
+//			return next.RoundTrip(req)
+//		})
+//	}
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts an ordinary function to the http.RoundTripper interface.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// WithMiddleware appends middleware to the executor's chain. The first middleware passed
+// across all WithMiddleware options is the outermost one.
+func WithMiddleware(middleware ...Middleware) HttpOption {
+	return func(cfg *HttpConfig) {
+		cfg.Middleware = append(cfg.Middleware, middleware...)
+	}
+}
+
+// ChainMiddleware wraps base with middleware so that middleware[0] runs first.
+// A nil base means http.DefaultTransport.
+func ChainMiddleware(base http.RoundTripper, middleware ...Middleware) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	for i := len(middleware) - 1; i >= 0; i-- {
+		if middleware[i] != nil {
+			base = middleware[i](base)
+		}
+	}
+	return base
+}
+
+// WithHttpClient sends requests with client, which can carry a custom transport, proxy,
+// TLS configuration, connection pool settings or timeout. The client is copied, so it
+// can be shared with other code safely.
+func WithHttpClient(client *http.Client) HttpOption {
+	return func(cfg *HttpConfig) {
+		cfg.HttpClient = client
+	}
+}
+
+// WithTransport sends requests through transport, for example an *http.Transport with a
+// proxy, custom TLS roots or tuned connection pooling.
+func WithTransport(transport http.RoundTripper) HttpOption {
+	return func(cfg *HttpConfig) {
+		cfg.Transport = transport
+	}
+}
+
+// WithTimeout limits each request attempt to timeout. Use a context deadline to bound a
+// single call, retries included.
+func WithTimeout(timeout time.Duration) HttpOption {
+	return func(cfg *HttpConfig) {
+		cfg.Timeout = timeout
+	}
+}
+
+// WithExecutor replaces the SDK's HTTP executor with executor. The other options, such as
+// retries and rate limiting, do not apply to a custom executor.
+func WithExecutor(executor HttpExecutor) HttpOption {
+	return func(cfg *HttpConfig) {
+		cfg.Executor = executor
+	}
+}
+
+// CacheStore stores response bodies for the opt-in response cache. Implementations must be
+// safe for concurrent use. See the common/httpcache package for an in-memory LRU store.
+type CacheStore interface {
+	// Get returns the value stored under key, or false if it is missing or expired.
+	Get(key string) ([]byte, bool)
+	// Set stores value under key for ttl.
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// CacheRule caches successful GET responses whose URL path matches Pattern for TTL.
+// Pattern uses path.Match syntax, so "*" matches a single path segment.
+type CacheRule struct {
+	Pattern string
+	TTL     time.Duration
+}
+
+// CachePolicy configures the response cache.
+type CachePolicy struct {
+	// Store holds cached responses. Nil means a private in-memory LRU store.
+	// Share a Store between clients to share cached responses.
+	Store CacheStore
+	// Rules selects the endpoints to cache. The first matching rule wins; requests
+	// matching no rule are never cached.
+	Rules []CacheRule
+}
+
+// DefaultCacheRules returns rules for endpoints whose data rarely changes: aggregation tokens,
+// liquidity sources and approve spender, the fusion and fusion plus settlement contracts,
+// whitelisted tokens, spot price currencies and NFT supported chains.
+func DefaultCacheRules() []CacheRule {
+	return []CacheRule{
+		{Pattern: "/swap/*/*/tokens", TTL: time.Hour},
+		{Pattern: "/swap/*/*/liquidity-sources", TTL: time.Hour},
+		{Pattern: "/swap/*/*/approve/spender", TTL: time.Hour},
+		{Pattern: "/fusion/orders/*/*/order/settlement", TTL: time.Hour},
+		{Pattern: "/fusion-plus/orders/*/order/escrow", TTL: time.Hour},
+		{Pattern: "/token/*/[0-9]*", TTL: 10 * time.Minute},
+		{Pattern: "/token/*/*/token-list", TTL: 10 * time.Minute},
+		{Pattern: "/price/*/*/currencies", TTL: time.Hour},
+		{Pattern: "/nft/*/supportedchains", TTL: time.Hour},
+	}
+}
+
+// WithCache enables the response cache. Concurrent identical GET requests to a cached
+// endpoint are collapsed into a single API call.
+func WithCache(policy CachePolicy) HttpOption {
+	return func(cfg *HttpConfig) {
+		cfg.Cache = &policy
+	}
+}
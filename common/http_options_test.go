@@ -0,0 +1,73 @@
+package common
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHttpConfig(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Second}
+
+	tests := []struct {
+		name                  string
+		opts                  []HttpOption
+		expectedRetry         *RetryPolicy
+		expectedMiddlewareLen int
+	}{
+		{
+			name: "No options",
+		},
+		{
+			name:          "Later retry policy overrides an earlier one",
+			opts:          []HttpOption{WithRetryPolicy(DefaultRetryPolicy()), WithRetryPolicy(policy)},
+			expectedRetry: &policy,
+		},
+		{
+			name: "Middleware accumulates and nil options are skipped",
+			opts: []HttpOption{
+				WithMiddleware(func(next http.RoundTripper) http.RoundTripper { return next }),
+				nil,
+				WithMiddleware(func(next http.RoundTripper) http.RoundTripper { return next }),
+			},
+			expectedMiddlewareLen: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewHttpConfig(tc.opts...)
+			assert.Equal(t, tc.expectedRetry, cfg.RetryPolicy)
+			assert.Len(t, cfg.Middleware, tc.expectedMiddlewareLen)
+		})
+	}
+}
+
+func TestChainMiddleware(t *testing.T) {
+	var order []string
+	tag := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name+" before")
+				resp, err := next.RoundTrip(req)
+				order = append(order, name+" after")
+				return resp, err
+			})
+		}
+	}
+	base := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		order = append(order, "transport")
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+
+	rt := ChainMiddleware(base, tag("outer"), nil, tag("inner"))
+	req, err := http.NewRequest(http.MethodGet, "https://example.com", nil)
+	require.NoError(t, err)
+	_, err = rt.RoundTrip(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"outer before", "inner before", "transport", "inner after", "outer after"}, order)
+}
@@ -0,0 +1,101 @@
+// Package httpcache provides the default in-memory store for the SDK's opt-in response cache.
+//
+//	cache := common.WithCache(common.CachePolicy{
+//		Store: httpcache.NewLRU(1024),
+//		Rules: common.DefaultCacheRules(),
+//	})
+package httpcache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// DefaultCapacity is the number of entries kept by the store the SDK creates when
+// common.CachePolicy.Store is nil.
+const DefaultCapacity = 256
+
+// LRU is an in-memory common.CacheStore holding up to a fixed number of entries. When full,
+// the least recently used entry is evicted. Expired entries are dropped on access.
+// LRU is safe for concurrent use and can be shared between clients.
+type LRU struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+	now      func() time.Time
+}
+
+type entry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewLRU returns a store holding at most capacity entries. Values below 1 mean DefaultCapacity.
+func NewLRU(capacity int) *LRU {
+	if capacity < 1 {
+		capacity = DefaultCapacity
+	}
+	return &LRU{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+		now:      time.Now,
+	}
+}
+
+// Get returns the value stored under key if it has not expired.
+func (c *LRU) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*entry)
+	if !c.now().Before(e.expiresAt) {
+		c.remove(el)
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return e.value, true
+}
+
+// Set stores value under key for ttl, evicting the least recently used entry when full.
+func (c *LRU) Set(key string, value []byte, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.now().Add(ttl)
+	if el, ok := c.entries[key]; ok {
+		e := el.Value.(*entry)
+		e.value = value
+		e.expiresAt = expiresAt
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&entry{key: key, value: value, expiresAt: expiresAt})
+	for c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+	}
+}
+
+// Len returns the number of entries, including expired ones not yet dropped.
+func (c *LRU) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *LRU) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.entries, el.Value.(*entry).key)
+}
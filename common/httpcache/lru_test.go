@@ -0,0 +1,88 @@
+package httpcache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRU(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type op struct {
+		set     bool
+		key     string
+		value   string
+		ttl     time.Duration
+		elapsed time.Duration
+		found   bool
+	}
+
+	tests := []struct {
+		name     string
+		capacity int
+		ops      []op
+	}{
+		{
+			name:     "Stored value is returned until it expires",
+			capacity: 2,
+			ops: []op{
+				{set: true, key: "a", value: "1", ttl: time.Minute},
+				{key: "a", value: "1", elapsed: 59 * time.Second, found: true},
+				{key: "a", elapsed: time.Minute, found: false},
+			},
+		},
+		{
+			name:     "Least recently used entry is evicted",
+			capacity: 2,
+			ops: []op{
+				{set: true, key: "a", value: "1", ttl: time.Minute},
+				{set: true, key: "b", value: "2", ttl: time.Minute},
+				{key: "a", value: "1", found: true},
+				{set: true, key: "c", value: "3", ttl: time.Minute},
+				{key: "b", found: false},
+				{key: "a", value: "1", found: true},
+				{key: "c", value: "3", found: true},
+			},
+		},
+		{
+			name:     "Overwriting refreshes value and expiry",
+			capacity: 2,
+			ops: []op{
+				{set: true, key: "a", value: "1", ttl: time.Second},
+				{set: true, key: "a", value: "2", ttl: time.Minute},
+				{key: "a", value: "2", elapsed: 30 * time.Second, found: true},
+			},
+		},
+		{
+			name:     "Zero TTL is not stored",
+			capacity: 2,
+			ops: []op{
+				{set: true, key: "a", value: "1"},
+				{key: "a", found: false},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewLRU(tc.capacity)
+			now := start
+			c.now = func() time.Time { return now }
+			for i, o := range tc.ops {
+				now = start.Add(o.elapsed)
+				if o.set {
+					c.Set(o.key, []byte(o.value), o.ttl)
+					continue
+				}
+				value, found := c.Get(o.key)
+				assert.Equal(t, o.found, found, "op %d", i)
+				if o.found {
+					assert.Equal(t, o.value, string(value), "op %d", i)
+				}
+			}
+			assert.LessOrEqual(t, c.Len(), tc.capacity)
+		})
+	}
+}
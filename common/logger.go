@@ -0,0 +1,21 @@
+package common
+
+import "log/slog"
+
+// WithLogger sends debug logs of API requests to logger: the method, endpoint template,
+// query parameters, response status and timing of each request, plus the hashes of orders
+// placed by the fusion, fusionplus and orderbook clients. API keys, signatures and other
+// secrets are always redacted.
+func WithLogger(logger *slog.Logger) HttpOption {
+	return func(cfg *HttpConfig) {
+		cfg.Logger = logger
+	}
+}
+
+// WithWalletLogger sends debug logs of the transactions built, signed and broadcast with
+// the wallet to logger. Private keys and signatures are never logged.
+func WithWalletLogger(logger *slog.Logger) WalletOption {
+	return func(cfg *WalletConfig) {
+		cfg.Logger = logger
+	}
+}
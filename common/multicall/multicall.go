@@ -0,0 +1,261 @@
+// Package multicall batches read-only contract calls into Multicall3 aggregate3 calls. Each
+// call may be allowed to fail on its own, and large batches are split into several eth_calls
+// so they stay under node request and gas limits:
+//
+//	client, err := multicall.NewClient(ethClient, constants.EthereumChainId, multicall.Config{})
+//	balanceOf, err := multicall.NewCall(token, erc20ABI, "balanceOf", holder)
+//	results, err := client.Aggregate3(ctx, []multicall.Call{balanceOf, ...})
+//	balance, err := multicall.Decode[*big.Int](results[0], erc20ABI, "balanceOf")
+package multicall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/1inch/1inch-sdk-go/v4/common/revert"
+	"github.com/1inch/1inch-sdk-go/v4/constants"
+)
+
+const aggregate3Method = "aggregate3"
+
+const (
+	// DefaultMaxCalls is the number of calls per batch when Config.MaxCalls is zero.
+	DefaultMaxCalls = 500
+	// DefaultMaxCalldataSize is the encoded size of a batch, in bytes, when
+	// Config.MaxCalldataSize is zero. It keeps requests well under common node body limits.
+	DefaultMaxCalldataSize = 128 * 1024
+)
+
+// ErrCallFailed is returned by the decode helpers for calls that reverted.
+var ErrCallFailed = errors.New("call failed")
+
+// Call is one contract call in a batch.
+type Call struct {
+	Target   gethCommon.Address
+	CallData []byte
+	// AllowFailure lets the call revert without reverting its batch. When it is false, a
+	// revert makes Aggregate3 return an error.
+	AllowFailure bool
+	// Gas is an optional estimate of the gas the call uses. It only matters when
+	// Config.MaxGas is set.
+	Gas uint64
+}
+
+// Result is the outcome of one call.
+type Result struct {
+	Success bool
+	// ReturnData is the call's output, or its revert data when Success is false
+	ReturnData []byte
+}
+
+// Config tunes a Client. The zero value uses the Multicall3 contract of the chain and the
+// default batch limits.
+type Config struct {
+	// Address overrides the Multicall3 contract address.
+	Address *gethCommon.Address
+	// MaxCalls is the maximum number of calls per batch.
+	MaxCalls int
+	// MaxCalldataSize is the maximum encoded size of a batch in bytes.
+	MaxCalldataSize int
+	// MaxGas, when set, limits the sum of Call.Gas per batch, so batches stay under the
+	// node's gas cap for eth_call.
+	MaxGas uint64
+}
+
+// Client sends batches of calls to a Multicall3 contract.
+type Client struct {
+	caller  ethereum.ContractCaller
+	address gethCommon.Address
+	abi     *abi.ABI
+	cfg     Config
+}
+
+// NewClient returns a client that sends batches through caller, typically an
+// *ethclient.Client, to the Multicall3 contract of the chain.
+func NewClient(caller ethereum.ContractCaller, chainId uint64, cfg Config) (*Client, error) {
+	contractABI, err := abi.JSON(strings.NewReader(constants.Multicall3ABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse abi: %w", err)
+	}
+	if cfg.MaxCalls <= 0 {
+		cfg.MaxCalls = DefaultMaxCalls
+	}
+	if cfg.MaxCalldataSize <= 0 {
+		cfg.MaxCalldataSize = DefaultMaxCalldataSize
+	}
+	address := Address(chainId)
+	if cfg.Address != nil {
+		address = *cfg.Address
+	}
+	return &Client{
+		caller:  caller,
+		address: address,
+		abi:     &contractABI,
+		cfg:     cfg,
+	}, nil
+}
+
+// Address returns the Multicall3 contract address of a chain. Chains without their own
+// deployment use the canonical address.
+func Address(chainId uint64) gethCommon.Address {
+	switch chainId {
+	case constants.ZkSyncEraChainId:
+		return gethCommon.HexToAddress(constants.Multicall3ZkSyncEra)
+	default:
+		return gethCommon.HexToAddress(constants.Multicall3Address)
+	}
+}
+
+// NewCall packs a call to method of contractABI at target.
+func NewCall(target gethCommon.Address, contractABI *abi.ABI, method string, args ...any) (Call, error) {
+	data, err := contractABI.Pack(method, args...)
+	if err != nil {
+		return Call{}, fmt.Errorf("failed to pack %s: %w", method, err)
+	}
+	return Call{Target: target, CallData: data}, nil
+}
+
+// Aggregate3 executes calls and returns their results in the same order. The calls are
+// split into batches within the configured limits and the batches are sent one after
+// another, so results from different batches may come from different blocks.
+func (c *Client) Aggregate3(ctx context.Context, calls []Call) ([]Result, error) {
+	results := make([]Result, 0, len(calls))
+	for _, batch := range c.batches(calls) {
+		batchResults, err := c.aggregate3(ctx, batch)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, batchResults...)
+	}
+	return results, nil
+}
+
+// call3 and result3 mirror the Multicall3 Call3 and Result structs for ABI encoding
+type call3 struct {
+	Target       gethCommon.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+type result3 struct {
+	Success    bool   `json:"success"`
+	ReturnData []byte `json:"returnData"`
+}
+
+func (c *Client) aggregate3(ctx context.Context, calls []Call) ([]Result, error) {
+	args := make([]call3, len(calls))
+	for i, call := range calls {
+		args[i] = call3{Target: call.Target, AllowFailure: call.AllowFailure, CallData: call.CallData}
+	}
+	data, err := c.abi.Pack(aggregate3Method, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack message: %w", err)
+	}
+
+	resp, err := c.caller.CallContract(ctx, ethereum.CallMsg{To: &c.address, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call multicall contract: %w", err)
+	}
+	if len(resp) == 0 {
+		return nil, fmt.Errorf("multicall contract %s returned no data: it may not be deployed on this chain", c.address.Hex())
+	}
+
+	outputs, err := c.abi.Unpack(aggregate3Method, resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack multicall response: %w", err)
+	}
+	var decoded []result3
+	if err := c.abi.Methods[aggregate3Method].Outputs.Copy(&decoded, outputs); err != nil {
+		return nil, fmt.Errorf("failed to unpack multicall response: %w", err)
+	}
+	if len(decoded) != len(calls) {
+		return nil, fmt.Errorf("multicall returned %d results for %d calls", len(decoded), len(calls))
+	}
+
+	results := make([]Result, len(decoded))
+	for i, r := range decoded {
+		results[i] = Result{Success: r.Success, ReturnData: r.ReturnData}
+	}
+	return results, nil
+}
+
+// batches splits calls so that no batch exceeds the configured number of calls, encoded
+// size or gas. A call that alone exceeds a limit gets a batch of its own.
+func (c *Client) batches(calls []Call) [][]Call {
+	var batches [][]Call
+	start, size := 0, 0
+	var gas uint64
+	for i, call := range calls {
+		callSize := encodedSize(call)
+		full := i-start >= c.cfg.MaxCalls ||
+			size+callSize > c.cfg.MaxCalldataSize ||
+			(c.cfg.MaxGas > 0 && gas+call.Gas > c.cfg.MaxGas)
+		if full && i > start {
+			batches = append(batches, calls[start:i])
+			start, size, gas = i, 0, 0
+		}
+		size += callSize
+		gas += call.Gas
+	}
+	if start < len(calls) {
+		batches = append(batches, calls[start:])
+	}
+	return batches
+}
+
+// encodedSize is the number of bytes a call adds to the aggregate3 calldata: its offset in
+// the array, the three head words, the length word of callData and the padded callData
+func encodedSize(call Call) int {
+	return 5*32 + (len(call.CallData)+31)/32*32
+}
+
+// Decode unpacks the single output of method from a successful result.
+func Decode[T any](result Result, contractABI *abi.ABI, method string) (T, error) {
+	var out T
+	if !result.Success {
+		return out, revertError(result)
+	}
+	outputs, err := contractABI.Unpack(method, result.ReturnData)
+	if err != nil {
+		return out, fmt.Errorf("failed to unpack %s: %w", method, err)
+	}
+	if len(outputs) != 1 {
+		return out, fmt.Errorf("%s has %d outputs, use DecodeInto", method, len(outputs))
+	}
+	if err := contractABI.Methods[method].Outputs.Copy(&out, outputs); err != nil {
+		return out, fmt.Errorf("failed to unpack %s: %w", method, err)
+	}
+	return out, nil
+}
+
+// DecodeInto unpacks the outputs of method from a successful result into out, a pointer to a
+// struct with a field per output or to a single value.
+func DecodeInto(result Result, contractABI *abi.ABI, method string, out any) error {
+	if !result.Success {
+		return revertError(result)
+	}
+	if err := contractABI.UnpackIntoInterface(out, method, result.ReturnData); err != nil {
+		return fmt.Errorf("failed to unpack %s: %w", method, err)
+	}
+	return nil
+}
+
+func revertError(result Result) error {
+	if len(result.ReturnData) == 0 {
+		return ErrCallFailed
+	}
+	if reason, err := abi.UnpackRevert(result.ReturnData); err == nil {
+		return fmt.Errorf("%w: %s", ErrCallFailed, reason)
+	}
+	if decoded := revert.Decode(result.ReturnData); decoded.Name != "" {
+		return fmt.Errorf("%w: %w", ErrCallFailed, decoded)
+	}
+	return fmt.Errorf("%w: revert data %s", ErrCallFailed, hexutil.Encode(result.ReturnData))
+}
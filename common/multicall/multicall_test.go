@@ -0,0 +1,250 @@
+package multicall
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/v4/constants"
+)
+
+var (
+	token    = gethCommon.HexToAddress("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")
+	reverter = gethCommon.HexToAddress("0x000000000000000000000000000000000000dead")
+	holder   = gethCommon.HexToAddress("0x2c9b2dbdba8a9c969ac24153f5c1c23cb0e63914")
+)
+
+// fakeCaller executes aggregate3 calls against a token that returns the holder's balance and
+// a contract that always reverts, and records the size of each batch
+type fakeCaller struct {
+	t        *testing.T
+	batches  []int
+	balances map[gethCommon.Address]*big.Int
+}
+
+func (f *fakeCaller) CallContract(_ context.Context, msg ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	multicallABI := mustABI(f.t, constants.Multicall3ABI)
+	erc20ABI := mustABI(f.t, constants.Erc20ABI)
+	require.Equal(f.t, gethCommon.HexToAddress(constants.Multicall3Address), *msg.To)
+
+	args, err := multicallABI.Methods["aggregate3"].Inputs.Unpack(msg.Data[4:])
+	require.NoError(f.t, err)
+	var calls []call3
+	require.NoError(f.t, multicallABI.Methods["aggregate3"].Inputs.Copy(&calls, args))
+	f.batches = append(f.batches, len(calls))
+
+	results := make([]result3, len(calls))
+	for i, call := range calls {
+		if call.Target == reverter {
+			if !call.AllowFailure {
+				return nil, errors.New("execution reverted: Multicall3: call failed")
+			}
+			revert, err := abi.Arguments{{Type: mustType(f.t, "string")}}.Pack("not a token")
+			require.NoError(f.t, err)
+			results[i] = result3{ReturnData: append(gethCommon.FromHex("0x08c379a0"), revert...)}
+			continue
+		}
+		inputs, err := erc20ABI.Methods["balanceOf"].Inputs.Unpack(call.CallData[4:])
+		require.NoError(f.t, err)
+		balance, err := erc20ABI.Methods["balanceOf"].Outputs.Pack(f.balances[inputs[0].(gethCommon.Address)])
+		require.NoError(f.t, err)
+		results[i] = result3{Success: true, ReturnData: balance}
+	}
+	return multicallABI.Methods["aggregate3"].Outputs.Pack(results)
+}
+
+func mustABI(t *testing.T, definition string) *abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	require.NoError(t, err)
+	return &parsed
+}
+
+func mustType(t *testing.T, name string) abi.Type {
+	typ, err := abi.NewType(name, "", nil)
+	require.NoError(t, err)
+	return typ
+}
+
+func TestAggregate3(t *testing.T) {
+	erc20ABI := mustABI(t, constants.Erc20ABI)
+	balanceOf := func(gas uint64) Call {
+		call, err := NewCall(token, erc20ABI, "balanceOf", holder)
+		require.NoError(t, err)
+		call.Gas = gas
+		return call
+	}
+	repeat := func(call Call, n int) []Call {
+		calls := make([]Call, n)
+		for i := range calls {
+			calls[i] = call
+		}
+		return calls
+	}
+
+	tests := []struct {
+		name            string
+		config          Config
+		calls           []Call
+		expectedBatches []int
+		expectedSuccess []bool
+		expectedError   string
+	}{
+		{
+			name:            "Single batch",
+			calls:           repeat(balanceOf(0), 3),
+			expectedBatches: []int{3},
+			expectedSuccess: []bool{true, true, true},
+		},
+		{
+			name:            "Split by number of calls",
+			config:          Config{MaxCalls: 2},
+			calls:           repeat(balanceOf(0), 5),
+			expectedBatches: []int{2, 2, 1},
+			expectedSuccess: []bool{true, true, true, true, true},
+		},
+		{
+			name: "Split by calldata size",
+			// balanceOf encodes to 5 words plus 2 words of calldata
+			config:          Config{MaxCalldataSize: 3 * 7 * 32},
+			calls:           repeat(balanceOf(0), 7),
+			expectedBatches: []int{3, 3, 1},
+			expectedSuccess: []bool{true, true, true, true, true, true, true},
+		},
+		{
+			name:            "Split by gas",
+			config:          Config{MaxGas: 100_000},
+			calls:           []Call{balanceOf(40_000), balanceOf(40_000), balanceOf(40_000), balanceOf(150_000), balanceOf(1)},
+			expectedBatches: []int{2, 1, 1, 1},
+			expectedSuccess: []bool{true, true, true, true, true},
+		},
+		{
+			name:            "Allowed failure",
+			calls:           []Call{balanceOf(0), {Target: reverter, CallData: []byte{0x01}, AllowFailure: true}},
+			expectedBatches: []int{2},
+			expectedSuccess: []bool{true, false},
+		},
+		{
+			name:          "Failure not allowed",
+			calls:         []Call{balanceOf(0), {Target: reverter, CallData: []byte{0x01}}},
+			expectedError: "failed to call multicall contract: execution reverted: Multicall3: call failed",
+		},
+		{
+			name:            "No calls",
+			calls:           nil,
+			expectedSuccess: []bool{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			caller := &fakeCaller{t: t, balances: map[gethCommon.Address]*big.Int{holder: big.NewInt(42)}}
+			client, err := NewClient(caller, constants.EthereumChainId, tc.config)
+			require.NoError(t, err)
+
+			results, err := client.Aggregate3(context.Background(), tc.calls)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedBatches, caller.batches)
+
+			success := make([]bool, len(results))
+			for i, result := range results {
+				success[i] = result.Success
+				if result.Success {
+					balance, err := Decode[*big.Int](result, erc20ABI, "balanceOf")
+					require.NoError(t, err)
+					assert.Equal(t, int64(42), balance.Int64())
+				}
+			}
+			assert.Equal(t, tc.expectedSuccess, success)
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	erc20ABI := mustABI(t, constants.Erc20ABI)
+	symbol, err := erc20ABI.Methods["symbol"].Outputs.Pack("USDC")
+	require.NoError(t, err)
+	reason, err := abi.Arguments{{Type: mustType(t, "string")}}.Pack("not a token")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		result        Result
+		method        string
+		expected      string
+		expectedError string
+	}{
+		{
+			name:     "Success",
+			result:   Result{Success: true, ReturnData: symbol},
+			method:   "symbol",
+			expected: "USDC",
+		},
+		{
+			name:          "Revert reason",
+			result:        Result{ReturnData: append(gethCommon.FromHex("0x08c379a0"), reason...)},
+			method:        "symbol",
+			expectedError: "call failed: not a token",
+		},
+		{
+			name:          "Known custom error",
+			result:        Result{ReturnData: gethCommon.FromHex("0x5cd5d233")},
+			method:        "symbol",
+			expectedError: "call failed: execution reverted: BadSignature()",
+		},
+		{
+			name:          "Unknown custom error",
+			result:        Result{ReturnData: gethCommon.FromHex("0x12345678")},
+			method:        "symbol",
+			expectedError: "call failed: revert data 0x12345678",
+		},
+		{
+			name:          "Empty revert",
+			result:        Result{},
+			method:        "symbol",
+			expectedError: "call failed",
+		},
+		{
+			name:          "Wrong output type",
+			result:        Result{Success: true, ReturnData: symbol},
+			method:        "decimals",
+			expectedError: "failed to unpack decimals",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			decoded, err := Decode[string](tc.result, erc20ABI, tc.method)
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+				if !tc.result.Success {
+					assert.ErrorIs(t, err, ErrCallFailed)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, decoded)
+
+			var into string
+			require.NoError(t, DecodeInto(tc.result, erc20ABI, tc.method, &into))
+			assert.Equal(t, tc.expected, into)
+		})
+	}
+}
+
+func TestAddress(t *testing.T) {
+	assert.Equal(t, gethCommon.HexToAddress(constants.Multicall3Address), Address(constants.EthereumChainId))
+	assert.Equal(t, gethCommon.HexToAddress(constants.Multicall3Address), Address(59144))
+	assert.Equal(t, gethCommon.HexToAddress(constants.Multicall3ZkSyncEra), Address(constants.ZkSyncEraChainId))
+}
@@ -0,0 +1,46 @@
+package common
+
+import "time"
+
+// NodeStrategy decides which node endpoint serves a request when a wallet has several.
+type NodeStrategy int
+
+const (
+	// NodePriority sends every request to the first healthy endpoint, in the order given,
+	// and fails over to the next one.
+	NodePriority NodeStrategy = iota
+	// NodeRoundRobin spreads requests over the healthy endpoints in turn.
+	NodeRoundRobin
+)
+
+// NodePolicy configures how a wallet with several node endpoints uses them. Whatever the
+// strategy, a request that fails with a network error or an HTTP 429 or 5xx status is retried
+// on the next endpoint, and eth_sendRawTransaction is sent to every endpoint.
+type NodePolicy struct {
+	Strategy NodeStrategy
+	// HealthCheckInterval is how long an endpoint that failed is skipped before it is probed
+	// with eth_blockNumber and, if it answers, used again. Zero means 30 seconds.
+	HealthCheckInterval time.Duration
+	// Quorum is the number of endpoints that must return the same result for a request to
+	// one of QuorumMethods to succeed. Zero or one disables quorum reads.
+	Quorum int
+	// QuorumMethods are the JSON-RPC methods read with a quorum. Nil means
+	// eth_getTransactionCount and eth_call, which covers nonces and allowances.
+	QuorumMethods []string
+}
+
+// WithNodeURLs adds node endpoints to the wallet next to the node URL it is created with,
+// which stays the first endpoint. Only HTTP(S) endpoints can be combined.
+func WithNodeURLs(urls ...string) WalletOption {
+	return func(cfg *WalletConfig) {
+		cfg.NodeURLs = append(cfg.NodeURLs, urls...)
+	}
+}
+
+// WithNodePolicy sets how the wallet chooses among its node endpoints. It only matters when
+// WithNodeURLs adds endpoints; the default policy is NodePriority without quorum reads.
+func WithNodePolicy(policy NodePolicy) WalletOption {
+	return func(cfg *WalletConfig) {
+		cfg.NodePolicy = policy
+	}
+}
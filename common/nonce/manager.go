@@ -0,0 +1,135 @@
+// Package nonce provides a concurrency-safe local nonce manager for accounts that send many
+// transactions, such as approvals followed by swaps from several goroutines:
+//
+//	manager := nonce.NewManager()
+//	config, err := aggregation.NewConfiguration(aggregation.ConfigurationParams{
+//		...
+//		WalletOptions: []common.WalletOption{common.WithNonceManager(manager)},
+//	})
+//
+// The manager starts from the node's pending nonce and then counts locally, so building a
+// transaction costs no nonce lookup and two transactions never share a nonce.
+package nonce
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+)
+
+var _ common.NonceManager = (*Manager)(nil)
+
+// Manager is the default common.NonceManager. Released nonces are handed out again before
+// new ones, lowest first, so a transaction that failed to broadcast does not leave a gap
+// that would block every later transaction of the account. Manager is safe for concurrent use.
+type Manager struct {
+	mu     sync.Mutex
+	synced bool
+	// next is the lowest nonce never handed out since the last sync
+	next uint64
+	// reserved holds the nonces handed out and neither sent nor released
+	reserved map[uint64]struct{}
+	// released holds the nonces below next to hand out again, sorted
+	released []uint64
+	// highestSent is the highest nonce broadcast since the last sync, valid when anySent is set
+	highestSent uint64
+	anySent     bool
+}
+
+// NewManager returns a manager that syncs with the node on first use.
+func NewManager() *Manager {
+	return &Manager{reserved: make(map[uint64]struct{})}
+}
+
+func (m *Manager) Next(ctx context.Context, pending common.PendingNonceFunc) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.synced {
+		// The lock is held during the lookup so that concurrent first callers wait for the
+		// node instead of each starting from the same pending nonce
+		next, err := pending(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("failed to sync nonce: %w", err)
+		}
+		m.next = next
+		m.synced = true
+	}
+
+	var n uint64
+	if len(m.released) > 0 {
+		n = m.released[0]
+		m.released = m.released[1:]
+	} else {
+		n = m.next
+		m.next++
+	}
+	m.reserved[n] = struct{}{}
+	return n, nil
+}
+
+func (m *Manager) Sent(nonce uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.reserved, nonce)
+	if !m.anySent || nonce > m.highestSent {
+		m.highestSent = nonce
+		m.anySent = true
+	}
+}
+
+func (m *Manager) Release(nonce uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.reserved[nonce]; !ok {
+		return
+	}
+	delete(m.reserved, nonce)
+
+	if nonce+1 != m.next {
+		i, _ := slices.BinarySearch(m.released, nonce)
+		m.released = slices.Insert(m.released, i, nonce)
+		return
+	}
+	// Releasing the newest nonce shrinks the range instead, together with any released
+	// nonces directly below it
+	m.next = nonce
+	for len(m.released) > 0 && m.released[len(m.released)-1]+1 == m.next {
+		m.next--
+		m.released = m.released[:len(m.released)-1]
+	}
+}
+
+func (m *Manager) Resync() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.synced = false
+	m.reserved = make(map[uint64]struct{})
+	m.released = nil
+	m.anySent = false
+}
+
+// Gaps returns the released nonces below the highest nonce sent, in increasing order.
+// Transactions sent with higher nonces cannot be mined until a transaction is sent for each
+// gap; the next calls to Next fill them first.
+func (m *Manager) Gaps() []uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.anySent {
+		return nil
+	}
+	var gaps []uint64
+	for _, n := range m.released {
+		if n < m.highestSent {
+			gaps = append(gaps, n)
+		}
+	}
+	return gaps
+}
@@ -0,0 +1,140 @@
+package nonce
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pendingNonce returns a node stub that reports nonce and counts its lookups
+func pendingNonce(nonce uint64, lookups *int) func(ctx context.Context) (uint64, error) {
+	return func(ctx context.Context) (uint64, error) {
+		*lookups++
+		return nonce, nil
+	}
+}
+
+func TestManagerConcurrentNext(t *testing.T) {
+	m := NewManager()
+	lookups := 0
+	pending := pendingNonce(7, &lookups)
+
+	var mu sync.Mutex
+	var nonces []uint64
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n, err := m.Next(context.Background(), pending)
+			require.NoError(t, err)
+			mu.Lock()
+			nonces = append(nonces, n)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+	for i, n := range nonces {
+		assert.Equal(t, uint64(7+i), n)
+	}
+	assert.Equal(t, 1, lookups)
+}
+
+func TestManager(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		run           func(m *Manager, next func() uint64)
+		expectedNext  []uint64
+		expectedGaps  []uint64
+		expectedSyncs int
+	}{
+		{
+			name: "Released nonces are handed out again lowest first",
+			run: func(m *Manager, next func() uint64) {
+				n0, n1, n2, n3 := next(), next(), next(), next()
+				m.Sent(n0)
+				m.Release(n2)
+				m.Release(n1)
+				m.Sent(n3)
+			},
+			expectedNext:  []uint64{11, 12, 14},
+			expectedGaps:  []uint64{11, 12},
+			expectedSyncs: 1,
+		},
+		{
+			name: "Releasing the newest nonces shrinks the range",
+			run: func(m *Manager, next func() uint64) {
+				n0, n1, n2 := next(), next(), next()
+				m.Sent(n0)
+				m.Release(n1)
+				m.Release(n2)
+			},
+			expectedNext:  []uint64{11, 12},
+			expectedSyncs: 1,
+		},
+		{
+			name: "Releasing an unknown or sent nonce is ignored",
+			run: func(m *Manager, next func() uint64) {
+				n0 := next()
+				m.Sent(n0)
+				m.Release(n0)
+				m.Release(42)
+			},
+			expectedNext:  []uint64{11},
+			expectedSyncs: 1,
+		},
+		{
+			name: "Resync asks the node again",
+			run: func(m *Manager, next func() uint64) {
+				n0, n1 := next(), next()
+				m.Sent(n1)
+				m.Release(n0)
+				m.Resync()
+			},
+			expectedNext:  []uint64{10},
+			expectedSyncs: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := NewManager()
+			lookups := 0
+			pending := pendingNonce(10, &lookups)
+			next := func() uint64 {
+				n, err := m.Next(ctx, pending)
+				require.NoError(t, err)
+				return n
+			}
+
+			tc.run(m, next)
+			assert.Equal(t, tc.expectedGaps, m.Gaps())
+			for _, expected := range tc.expectedNext {
+				assert.Equal(t, expected, next())
+			}
+			assert.Equal(t, tc.expectedSyncs, lookups)
+		})
+	}
+}
+
+func TestManagerSyncError(t *testing.T) {
+	m := NewManager()
+	_, err := m.Next(context.Background(), func(ctx context.Context) (uint64, error) {
+		return 0, errors.New("node unavailable")
+	})
+	require.EqualError(t, err, "failed to sync nonce: node unavailable")
+
+	lookups := 0
+	n, err := m.Next(context.Background(), pendingNonce(3, &lookups))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), n)
+}
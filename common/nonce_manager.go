@@ -0,0 +1,39 @@
+package common
+
+import "context"
+
+// PendingNonceFunc returns an account's pending nonce from the node, which counts the
+// transactions waiting in the mempool.
+type PendingNonceFunc func(ctx context.Context) (uint64, error)
+
+// NonceManager hands out the nonces of one account's transactions locally, so that
+// transactions built concurrently, or before earlier ones are mined, get distinct,
+// consecutive nonces. The common/nonce package provides the default implementation.
+type NonceManager interface {
+	// Next reserves and returns the next nonce. pending is asked for the starting nonce on
+	// the first call and after Resync.
+	Next(ctx context.Context, pending PendingNonceFunc) (uint64, error)
+	// Sent records that the transaction with nonce was broadcast.
+	Sent(nonce uint64)
+	// Release returns a reserved nonce whose transaction was not broadcast, so that it is
+	// handed out again instead of leaving a gap.
+	Release(nonce uint64)
+	// Resync discards the local state; the next call to Next asks the node again.
+	Resync()
+}
+
+// WithNonceManager makes the wallet take nonces from manager: Nonce reserves the next nonce
+// instead of reading the latest one from the node, and BroadcastTransaction reports the
+// outcome back, resyncing on "nonce too low" and "already known" errors and releasing the
+// nonce when the node rejects the transaction. Timeouts and connection errors leave the nonce
+// reserved, because the node may have accepted the transaction. Transaction builders created
+// with the same options release the nonces of builds that fail; a transaction that is built
+// but never broadcast must be released by the caller with manager.Release.
+//
+// Use one manager per account. Wallets of several clients that sign for the same account
+// should share it.
+func WithNonceManager(manager NonceManager) WalletOption {
+	return func(cfg *WalletConfig) {
+		cfg.NonceManager = manager
+	}
+}
@@ -0,0 +1,228 @@
+// Package offline moves transactions between an online machine, which builds them, and an
+// offline machine, which holds the key and signs them:
+//
+//	// Online: the wallet only knows the treasury address
+//	config, err := aggregation.NewConfiguration(aggregation.ConfigurationParams{
+//		...
+//		WalletOptions: []common.WalletOption{common.WithSigner(signer.NewWatchOnly(treasury))},
+//	})
+//	swap, err := client.GetSwap(ctx, params)
+//	unsigned, err := client.BuildUnsignedSwap(ctx, swap)
+//	document, err := json.Marshal(unsigned) // carry to the offline machine
+//
+//	// Offline
+//	wallet, err := offline.NewWallet(privateKey, constants.EthereumChainId)
+//	var unsigned offline.UnsignedTransaction
+//	err = json.Unmarshal(document, &unsigned)
+//	rawTransaction, err := offline.Sign(wallet, &unsigned) // carry back to the online machine
+//
+//	// Online
+//	signedTx, err := offline.ParseSigned(unsigned, rawTransaction)
+//	err = client.Wallet.BroadcastTransaction(ctx, signedTx)
+//	// or: txbroadcastClient.BroadcastPrivateTransaction(ctx, txbroadcast.BroadcastRequest{RawTransaction: rawTransaction})
+//
+// The document holds the unsigned transaction as RLP hex, with its nonce, fees and gas already
+// resolved, and human-readable metadata for review before signing. Decoding checks that the
+// metadata matches the transaction.
+package offline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+	web3_provider "github.com/1inch/1inch-sdk-go/v4/internal/web3-provider"
+)
+
+// Version is the version of the document format written by UnsignedTransaction.MarshalJSON.
+const Version = 1
+
+// Transaction kinds set by the aggregation client
+const (
+	KindSwap    = "swap"
+	KindApprove = "approve"
+)
+
+// UnsignedTransaction is a transaction waiting to be signed offline.
+type UnsignedTransaction struct {
+	Tx       *types.Transaction
+	Metadata Metadata
+}
+
+// Metadata describes an unsigned transaction for the person who signs it. Amounts are
+// decimal strings in wei.
+type Metadata struct {
+	Kind                 string              `json:"kind,omitempty"`
+	Description          string              `json:"description,omitempty"`
+	ChainId              uint64              `json:"chainId"`
+	From                 gethCommon.Address  `json:"from"`
+	To                   *gethCommon.Address `json:"to"`
+	Nonce                uint64              `json:"nonce"`
+	Gas                  uint64              `json:"gas"`
+	Value                string              `json:"value"`
+	GasPrice             string              `json:"gasPrice,omitempty"`
+	MaxFeePerGas         string              `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas string              `json:"maxPriorityFeePerGas,omitempty"`
+}
+
+type document struct {
+	Version        int           `json:"version"`
+	RawTransaction hexutil.Bytes `json:"rawTransaction"`
+	Metadata       Metadata      `json:"metadata"`
+}
+
+// NewUnsignedTransaction describes tx, which from sends on chainId. kind and description
+// are shown to the signer.
+func NewUnsignedTransaction(tx *types.Transaction, from gethCommon.Address, chainId uint64, kind string, description string) (*UnsignedTransaction, error) {
+	if isSigned(tx) {
+		return nil, errors.New("transaction is already signed")
+	}
+	if err := checkChainId(tx, chainId); err != nil {
+		return nil, err
+	}
+	metadata := describe(tx, chainId)
+	metadata.Kind = kind
+	metadata.Description = description
+	metadata.From = from
+	return &UnsignedTransaction{Tx: tx, Metadata: metadata}, nil
+}
+
+func (u UnsignedTransaction) MarshalJSON() ([]byte, error) {
+	raw, err := u.Tx.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode transaction: %w", err)
+	}
+	return json.Marshal(document{Version: Version, RawTransaction: raw, Metadata: u.Metadata})
+}
+
+func (u *UnsignedTransaction) UnmarshalJSON(data []byte) error {
+	var doc document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	if doc.Version != Version {
+		return fmt.Errorf("unsupported document version %d", doc.Version)
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(doc.RawTransaction); err != nil {
+		return fmt.Errorf("invalid raw transaction: %w", err)
+	}
+	if isSigned(tx) {
+		return errors.New("raw transaction is already signed")
+	}
+	if err := checkChainId(tx, doc.Metadata.ChainId); err != nil {
+		return err
+	}
+
+	// The signer reviews the metadata, so it must describe the transaction that gets signed
+	expected := describe(tx, doc.Metadata.ChainId)
+	expected.Kind, expected.Description, expected.From = doc.Metadata.Kind, doc.Metadata.Description, doc.Metadata.From
+	if !sameMetadata(expected, doc.Metadata) {
+		return errors.New("metadata does not match the raw transaction")
+	}
+	u.Tx, u.Metadata = tx, doc.Metadata
+	return nil
+}
+
+// NewWallet returns a wallet for the offline machine: it signs with privateKey and has no node
+// connection.
+func NewWallet(privateKey string, chainId uint64, opts ...common.WalletOption) (common.Wallet, error) {
+	return web3_provider.DefaultWalletOnlyProvider(privateKey, chainId, opts...)
+}
+
+// Sign signs u with wallet and returns the signed transaction as 0x-prefixed hex, ready for
+// ParseSigned or a raw transaction broadcast.
+func Sign(wallet common.Wallet, u *UnsignedTransaction) (string, error) {
+	if wallet.Address() != u.Metadata.From {
+		return "", fmt.Errorf("transaction is from %s, the wallet is %s", u.Metadata.From.Hex(), wallet.Address().Hex())
+	}
+	if uint64(wallet.ChainId()) != u.Metadata.ChainId {
+		return "", fmt.Errorf("transaction is for chain %d, the wallet is on chain %d", u.Metadata.ChainId, wallet.ChainId())
+	}
+	signedTx, err := wallet.Sign(u.Tx)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	raw, err := signedTx.MarshalBinary()
+	if err != nil {
+		return "", fmt.Errorf("failed to encode signed transaction: %w", err)
+	}
+	return hexutil.Encode(raw), nil
+}
+
+// ParseSigned decodes the signed transaction returned by the offline machine and checks that
+// it is u, signed by its sender.
+func ParseSigned(u *UnsignedTransaction, rawTransaction string) (*types.Transaction, error) {
+	raw, err := hexutil.Decode(rawTransaction)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signed transaction: %w", err)
+	}
+	signedTx := new(types.Transaction)
+	if err := signedTx.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("invalid signed transaction: %w", err)
+	}
+
+	signer := types.LatestSignerForChainID(new(big.Int).SetUint64(u.Metadata.ChainId))
+	if signer.Hash(signedTx) != signer.Hash(u.Tx) {
+		return nil, errors.New("signed transaction does not match the unsigned transaction")
+	}
+	sender, err := types.Sender(signer, signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %w", err)
+	}
+	if sender != u.Metadata.From {
+		return nil, fmt.Errorf("transaction was signed by %s instead of %s", sender.Hex(), u.Metadata.From.Hex())
+	}
+	return signedTx, nil
+}
+
+// describe returns the metadata that can be read from tx
+func describe(tx *types.Transaction, chainId uint64) Metadata {
+	m := Metadata{
+		ChainId: chainId,
+		To:      tx.To(),
+		Nonce:   tx.Nonce(),
+		Gas:     tx.Gas(),
+		Value:   tx.Value().String(),
+	}
+	switch tx.Type() {
+	case types.LegacyTxType, types.AccessListTxType:
+		m.GasPrice = tx.GasPrice().String()
+	default:
+		m.MaxFeePerGas = tx.GasFeeCap().String()
+		m.MaxPriorityFeePerGas = tx.GasTipCap().String()
+	}
+	return m
+}
+
+func sameMetadata(a Metadata, b Metadata) bool {
+	if (a.To == nil) != (b.To == nil) || (a.To != nil && *a.To != *b.To) {
+		return false
+	}
+	a.To, b.To = nil, nil
+	return a == b
+}
+
+// checkChainId checks the chain of typed transactions; legacy transactions only get theirs
+// when signed
+func checkChainId(tx *types.Transaction, chainId uint64) error {
+	if tx.Type() == types.LegacyTxType {
+		return nil
+	}
+	if !tx.ChainId().IsUint64() || tx.ChainId().Uint64() != chainId {
+		return fmt.Errorf("transaction is for chain %s, expected chain %d", tx.ChainId(), chainId)
+	}
+	return nil
+}
+
+// isSigned reports whether tx carries a signature
+func isSigned(tx *types.Transaction) bool {
+	v, r, s := tx.RawSignatureValues()
+	return v.Sign() != 0 || r.Sign() != 0 || s.Sign() != 0
+}
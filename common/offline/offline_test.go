@@ -0,0 +1,164 @@
+package offline
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+	"github.com/1inch/1inch-sdk-go/v4/common/signer"
+	"github.com/1inch/1inch-sdk-go/v4/constants"
+)
+
+const (
+	testPrivateKey  = "965e092fdfc08940d2bd05c7b5c7e1c51e283e92c7f52bbf1408973ae9a9acb7"
+	otherPrivateKey = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+)
+
+var router = gethCommon.HexToAddress(constants.AggregationRouterV6)
+
+func newTestTransactions(t *testing.T) map[string]*types.Transaction {
+	t.Helper()
+	return map[string]*types.Transaction{
+		"dynamic": types.NewTx(&types.DynamicFeeTx{
+			ChainID: big.NewInt(1), Nonce: 7, GasTipCap: big.NewInt(1e9), GasFeeCap: big.NewInt(30e9),
+			Gas: 250_000, To: &router, Value: big.NewInt(1e18), Data: []byte{0x12, 0xaa, 0x3c, 0xaf},
+		}),
+		"legacy": types.NewTx(&types.LegacyTx{
+			Nonce: 7, GasPrice: big.NewInt(3e9), Gas: 60_000, To: &router, Value: big.NewInt(0), Data: []byte{0x09, 0x5e, 0xa7, 0xb3},
+		}),
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for name, tx := range newTestTransactions(t) {
+		t.Run(name, func(t *testing.T) {
+			wallet, err := NewWallet(testPrivateKey, constants.EthereumChainId)
+			require.NoError(t, err)
+
+			unsigned, err := NewUnsignedTransaction(tx, wallet.Address(), constants.EthereumChainId, KindSwap, "swap 1 ETH")
+			require.NoError(t, err)
+			document, err := json.Marshal(unsigned)
+			require.NoError(t, err)
+			assert.Contains(t, string(document), `"rawTransaction":"0x`)
+			assert.Contains(t, string(document), `"kind":"swap"`)
+
+			var decoded UnsignedTransaction
+			require.NoError(t, json.Unmarshal(document, &decoded))
+			assert.Equal(t, unsigned.Metadata, decoded.Metadata)
+			assert.Equal(t, tx.Hash(), decoded.Tx.Hash())
+
+			rawTransaction, err := Sign(wallet, &decoded)
+			require.NoError(t, err)
+			signedTx, err := ParseSigned(unsigned, rawTransaction)
+			require.NoError(t, err)
+			assert.Equal(t, tx.Nonce(), signedTx.Nonce())
+			assert.Equal(t, tx.Data(), signedTx.Data())
+			assert.Equal(t, big.NewInt(1), signedTx.ChainId())
+		})
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	tx := newTestTransactions(t)["dynamic"]
+	unsigned, err := NewUnsignedTransaction(tx, gethCommon.HexToAddress("0x1"), constants.EthereumChainId, KindSwap, "")
+	require.NoError(t, err)
+	document, err := json.Marshal(unsigned)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		replace       [2]string
+		expectedError string
+	}{
+		{
+			name:          "Altered value",
+			replace:       [2]string{`"value":"1000000000000000000"`, `"value":"1"`},
+			expectedError: "metadata does not match the raw transaction",
+		},
+		{
+			name:          "Altered raw transaction",
+			replace:       [2]string{strings.ToLower(router.Hex()[2:]), "000000000000000000000000000000000000dead"},
+			expectedError: "metadata does not match the raw transaction",
+		},
+		{
+			name:          "Other chain",
+			replace:       [2]string{`"chainId":1`, `"chainId":10`},
+			expectedError: "transaction is for chain 1, expected chain 10",
+		},
+		{
+			name:          "Unknown version",
+			replace:       [2]string{`"version":1`, `"version":2`},
+			expectedError: "unsupported document version 2",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			altered := strings.Replace(string(document), tc.replace[0], tc.replace[1], 1)
+			require.NotEqual(t, string(document), altered)
+			var decoded UnsignedTransaction
+			require.EqualError(t, json.Unmarshal([]byte(altered), &decoded), tc.expectedError)
+		})
+	}
+}
+
+func TestSignAndParse(t *testing.T) {
+	txs := newTestTransactions(t)
+	wallet, err := NewWallet(testPrivateKey, constants.EthereumChainId)
+	require.NoError(t, err)
+	otherWallet, err := NewWallet(otherPrivateKey, constants.EthereumChainId)
+	require.NoError(t, err)
+	polygonWallet, err := NewWallet(testPrivateKey, constants.PolygonChainId)
+	require.NoError(t, err)
+	watchOnly, err := NewWallet("", constants.EthereumChainId, common.WithSigner(signer.NewWatchOnly(wallet.Address())))
+	require.NoError(t, err)
+
+	unsigned, err := NewUnsignedTransaction(txs["dynamic"], wallet.Address(), constants.EthereumChainId, KindSwap, "")
+	require.NoError(t, err)
+	other, err := NewUnsignedTransaction(txs["legacy"], wallet.Address(), constants.EthereumChainId, KindApprove, "")
+	require.NoError(t, err)
+	signedOther, err := Sign(wallet, other)
+	require.NoError(t, err)
+
+	t.Run("Wrong wallet", func(t *testing.T) {
+		_, err := Sign(otherWallet, unsigned)
+		require.ErrorContains(t, err, "transaction is from "+wallet.Address().Hex())
+	})
+	t.Run("Wrong chain", func(t *testing.T) {
+		_, err := Sign(polygonWallet, unsigned)
+		require.EqualError(t, err, "transaction is for chain 1, the wallet is on chain 137")
+	})
+	t.Run("Watch-only wallet", func(t *testing.T) {
+		_, err := Sign(watchOnly, unsigned)
+		require.ErrorIs(t, err, signer.ErrWatchOnly)
+	})
+	t.Run("Another transaction", func(t *testing.T) {
+		_, err := ParseSigned(unsigned, signedOther)
+		require.EqualError(t, err, "signed transaction does not match the unsigned transaction")
+	})
+	t.Run("Another signer", func(t *testing.T) {
+		impostor := *unsigned
+		impostor.Metadata.From = otherWallet.Address()
+		rawTransaction, err := Sign(otherWallet, &impostor)
+		require.NoError(t, err)
+		_, err = ParseSigned(unsigned, rawTransaction)
+		require.ErrorContains(t, err, "transaction was signed by "+otherWallet.Address().Hex())
+	})
+	t.Run("Not hex", func(t *testing.T) {
+		_, err := ParseSigned(unsigned, "signed")
+		require.ErrorContains(t, err, "invalid signed transaction")
+	})
+	t.Run("Already signed", func(t *testing.T) {
+		signedTx, err := wallet.Sign(txs["dynamic"])
+		require.NoError(t, err)
+		_, err = NewUnsignedTransaction(signedTx, wallet.Address(), constants.EthereumChainId, KindSwap, "")
+		require.EqualError(t, err, "transaction is already signed")
+	})
+}
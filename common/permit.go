@@ -0,0 +1,91 @@
+package common
+
+import (
+	"fmt"
+	"math/big"
+
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// PermitKind is the permit flavor of a token
+type PermitKind string
+
+const (
+	// PermitUnsupported tokens must be approved with a transaction
+	PermitUnsupported PermitKind = "unsupported"
+	// PermitERC2612 tokens take Permit(owner, spender, value, nonce, deadline), signed with TokenPermit
+	PermitERC2612 PermitKind = "erc2612"
+	// PermitDaiLike tokens take Permit(holder, spender, nonce, expiry, allowed), signed with TokenPermitDaiLike
+	PermitDaiLike PermitKind = "dai-like"
+)
+
+// PermitSupport is the result of Wallet.DetectPermit: the permit flavor of a token and the
+// EIP-712 domain whose separator matches the one the token reports.
+type PermitSupport struct {
+	Kind PermitKind
+	// Reason explains why the token does not support permit
+	Reason string
+
+	Token gethCommon.Address
+	Owner gethCommon.Address
+	// Nonce is the owner's current permit nonce
+	Nonce *big.Int
+
+	Domain                 apitypes.TypedDataDomain
+	DomainSeparator        gethCommon.Hash
+	IsDomainWithoutVersion bool
+	// FromEIP5267 reports that the domain was read from the token's eip712Domain() rather
+	// than reconstructed from name() and version()
+	FromEIP5267 bool
+}
+
+// Supported reports whether the token can be approved with a permit signature
+func (p *PermitSupport) Supported() bool {
+	return p.Kind == PermitERC2612 || p.Kind == PermitDaiLike
+}
+
+// PermitData returns the input of Wallet.TokenPermit for an ERC-2612 token
+func (p *PermitSupport) PermitData(spender gethCommon.Address, amount *big.Int, deadline int64) (*ContractPermitData, error) {
+	if p.Kind != PermitERC2612 {
+		return nil, fmt.Errorf("token %s does not support ERC-2612 permits: %s", p.Token.Hex(), p.describe())
+	}
+	return &ContractPermitData{
+		FromToken:              p.Token.Hex(),
+		Spender:                spender.Hex(),
+		Name:                   p.Domain.Name,
+		Version:                p.Domain.Version,
+		PublicAddress:          p.Owner.Hex(),
+		ChainId:                int((*big.Int)(p.Domain.ChainId).Int64()),
+		Nonce:                  p.Nonce.Int64(),
+		Deadline:               deadline,
+		Amount:                 amount,
+		IsDomainWithoutVersion: p.IsDomainWithoutVersion,
+	}, nil
+}
+
+// PermitDataDaiLike returns the input of Wallet.TokenPermitDaiLike for a DAI-like token
+func (p *PermitSupport) PermitDataDaiLike(spender gethCommon.Address, expiry int64) (*ContractPermitDataDaiLike, error) {
+	if p.Kind != PermitDaiLike {
+		return nil, fmt.Errorf("token %s does not support DAI-like permits: %s", p.Token.Hex(), p.describe())
+	}
+	return &ContractPermitDataDaiLike{
+		FromToken:              p.Token.Hex(),
+		Spender:                spender.Hex(),
+		Name:                   p.Domain.Name,
+		Version:                p.Domain.Version,
+		Holder:                 p.Owner.Hex(),
+		ChainId:                int((*big.Int)(p.Domain.ChainId).Int64()),
+		Nonce:                  p.Nonce.Int64(),
+		Expiry:                 expiry,
+		Allowed:                true,
+		IsDomainWithoutVersion: p.IsDomainWithoutVersion,
+	}, nil
+}
+
+func (p *PermitSupport) describe() string {
+	if p.Kind == PermitUnsupported {
+		return p.Reason
+	}
+	return "it uses " + string(p.Kind) + " permits"
+}
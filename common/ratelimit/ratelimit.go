@@ -0,0 +1,200 @@
+// Package ratelimit provides a token bucket rate limiter that can be shared by every SDK client
+// using the same API key, so that together they stay below the key's requests-per-second limit.
+//
+// Create one Limiter per process and pass it to each client's configuration:
+//
+//	limiter := ratelimit.NewLimiter(ratelimit.Rate{RequestsPerSecond: 1, Burst: 1})
+//	opts := []common.HttpOption{common.WithRateLimiter(limiter)}
+//
+//	aggregationCfg, err := aggregation.NewConfiguration(aggregation.ConfigurationParams{..., HttpOptions: opts})
+//	balancesCfg, err := balances.NewConfiguration(balances.ConfigurationParams{..., HttpOptions: opts})
+package ratelimit
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Rate is a token bucket refill rate.
+type Rate struct {
+	// RequestsPerSecond is the sustained rate. Zero or negative means unlimited.
+	RequestsPerSecond float64
+	// Burst is the number of requests that may be sent back to back. Values below 1 are treated as 1.
+	Burst int
+}
+
+// Limiter keeps one token bucket per API key, plus one per API key and endpoint family
+// for every family registered with SetFamilyRate. A request waits for a token from each
+// bucket that applies to it. Limiter is safe for concurrent use.
+type Limiter struct {
+	mu       sync.Mutex
+	rate     Rate
+	families map[string]Rate
+	buckets  map[bucketKey]*bucket
+	now      func() time.Time
+}
+
+type bucketKey struct {
+	apiKey string
+	family string
+}
+
+// NewLimiter returns a limiter applying rate to every API key.
+func NewLimiter(rate Rate) *Limiter {
+	return &Limiter{
+		rate:     rate,
+		families: make(map[string]Rate),
+		buckets:  make(map[bucketKey]*bucket),
+		now:      time.Now,
+	}
+}
+
+// SetFamilyRate adds a limit for requests whose path starts with pathPrefix (for example
+// "/fusion/"), applied per API key on top of the overall rate. When several prefixes match,
+// the longest one wins. Changing the rate of a family already in use applies to its existing
+// buckets too. It returns l to allow chaining.
+func (l *Limiter) SetFamilyRate(pathPrefix string, rate Rate) *Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.families[pathPrefix] = rate
+	now := l.now()
+	for key, b := range l.buckets {
+		if key.family == pathPrefix {
+			b.setRate(rate, now)
+		}
+	}
+	return l
+}
+
+// Wait blocks until a request for apiKey to path may be sent. It returns the context's error
+// if ctx is done first, in which case no token is consumed.
+func (l *Limiter) Wait(ctx context.Context, apiKey string, path string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	now := l.now()
+	buckets := []*bucket{l.bucket(bucketKey{apiKey: apiKey}, l.rate)}
+	if family, ok := l.family(path); ok {
+		buckets = append(buckets, l.bucket(bucketKey{apiKey: apiKey, family: family}, l.families[family]))
+	}
+	var wait time.Duration
+	for _, b := range buckets {
+		if d := b.reserve(now); d > wait {
+			wait = d
+		}
+	}
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		for _, b := range buckets {
+			b.cancel()
+		}
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// family returns the longest registered prefix matching path. Callers must hold l.mu.
+func (l *Limiter) family(path string) (string, bool) {
+	best, found := "", false
+	for prefix := range l.families {
+		if strings.HasPrefix(path, prefix) && len(prefix) >= len(best) {
+			best, found = prefix, true
+		}
+	}
+	return best, found
+}
+
+// bucket returns the bucket for key, creating it full on first use. Callers must hold l.mu.
+func (l *Limiter) bucket(key bucketKey, rate Rate) *bucket {
+	b, ok := l.buckets[key]
+	if !ok {
+		b = newBucket(rate, l.now())
+		l.buckets[key] = b
+	}
+	return b
+}
+
+type bucket struct {
+	rate   Rate
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(rate Rate, now time.Time) *bucket {
+	if rate.Burst < 1 {
+		rate.Burst = 1
+	}
+	return &bucket{
+		rate:   rate,
+		tokens: float64(rate.Burst),
+		last:   now,
+	}
+}
+
+// setRate refills the bucket at its old rate up to now and continues at rate. Tokens above
+// the new burst are dropped, and callers already queued keep their wait.
+func (b *bucket) setRate(rate Rate, now time.Time) {
+	b.refill(now)
+	if rate.Burst < 1 {
+		rate.Burst = 1
+	}
+	b.rate = rate
+	b.last = now
+	if burst := float64(rate.Burst); b.tokens > burst {
+		b.tokens = burst
+	}
+}
+
+// refill adds the tokens accrued since the last refill, up to the burst.
+func (b *bucket) refill(now time.Time) {
+	if b.rate.RequestsPerSecond <= 0 {
+		return
+	}
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * b.rate.RequestsPerSecond
+		if burst := float64(b.rate.Burst); b.tokens > burst {
+			b.tokens = burst
+		}
+		b.last = now
+	}
+}
+
+// reserve takes a token and returns how long the caller must wait before using it.
+// The token count may go negative, which queues callers in arrival order.
+func (b *bucket) reserve(now time.Time) time.Duration {
+	if b.rate.RequestsPerSecond <= 0 {
+		return 0
+	}
+	b.refill(now)
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate.RequestsPerSecond * float64(time.Second))
+}
+
+// cancel returns a token taken by reserve whose request was abandoned.
+func (b *bucket) cancel() {
+	if b.rate.RequestsPerSecond <= 0 {
+		return
+	}
+	b.tokens++
+	if burst := float64(b.rate.Burst); b.tokens > burst {
+		b.tokens = burst
+	}
+}
@@ -0,0 +1,139 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucketReserve(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		rate     Rate
+		offsets  []time.Duration
+		expected []time.Duration
+	}{
+		{
+			name:     "Burst is served immediately, then callers queue",
+			rate:     Rate{RequestsPerSecond: 2, Burst: 2},
+			offsets:  []time.Duration{0, 0, 0, 0},
+			expected: []time.Duration{0, 0, 500 * time.Millisecond, time.Second},
+		},
+		{
+			name:     "Tokens refill over time",
+			rate:     Rate{RequestsPerSecond: 1, Burst: 1},
+			offsets:  []time.Duration{0, time.Second, 1500 * time.Millisecond},
+			expected: []time.Duration{0, 0, 500 * time.Millisecond},
+		},
+		{
+			name:     "Refill is capped at the burst",
+			rate:     Rate{RequestsPerSecond: 10, Burst: 1},
+			offsets:  []time.Duration{0, time.Minute, time.Minute},
+			expected: []time.Duration{0, 0, 100 * time.Millisecond},
+		},
+		{
+			name:     "Unlimited rate never waits",
+			rate:     Rate{},
+			offsets:  []time.Duration{0, 0, 0},
+			expected: []time.Duration{0, 0, 0},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b := newBucket(tc.rate, start)
+			for i, offset := range tc.offsets {
+				assert.Equal(t, tc.expected[i], b.reserve(start.Add(offset)), "reservation %d", i)
+			}
+		})
+	}
+}
+
+func TestLimiterWait(t *testing.T) {
+	tests := []struct {
+		name        string
+		limiter     func() *Limiter
+		requests    []struct{ apiKey, path string }
+		minDuration time.Duration
+	}{
+		{
+			name: "Requests with the same key share a bucket",
+			limiter: func() *Limiter {
+				return NewLimiter(Rate{RequestsPerSecond: 50, Burst: 1})
+			},
+			requests:    []struct{ apiKey, path string }{{"a", "/swap/"}, {"a", "/balance/"}, {"a", "/fusion/"}},
+			minDuration: 40 * time.Millisecond,
+		},
+		{
+			name: "Different keys have separate buckets",
+			limiter: func() *Limiter {
+				return NewLimiter(Rate{RequestsPerSecond: 1, Burst: 1})
+			},
+			requests: []struct{ apiKey, path string }{{"a", "/swap/"}, {"b", "/swap/"}, {"c", "/swap/"}},
+		},
+		{
+			name: "Family rate applies on top of the key rate",
+			limiter: func() *Limiter {
+				return NewLimiter(Rate{RequestsPerSecond: 1000, Burst: 10}).
+					SetFamilyRate("/fusion/", Rate{RequestsPerSecond: 50, Burst: 1})
+			},
+			requests:    []struct{ apiKey, path string }{{"a", "/fusion/quoter"}, {"a", "/fusion/relayer"}, {"a", "/fusion/orders"}},
+			minDuration: 40 * time.Millisecond,
+		},
+		{
+			name: "Other families are not throttled by a family rate",
+			limiter: func() *Limiter {
+				return NewLimiter(Rate{RequestsPerSecond: 1000, Burst: 10}).
+					SetFamilyRate("/fusion/", Rate{RequestsPerSecond: 1, Burst: 1})
+			},
+			requests: []struct{ apiKey, path string }{{"a", "/fusion/quoter"}, {"a", "/swap/quote"}, {"a", "/balance/"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			l := tc.limiter()
+			start := time.Now()
+			for _, r := range tc.requests {
+				require.NoError(t, l.Wait(context.Background(), r.apiKey, r.path))
+			}
+			elapsed := time.Since(start)
+			assert.GreaterOrEqual(t, elapsed, tc.minDuration)
+			if tc.minDuration == 0 {
+				assert.Less(t, elapsed, 500*time.Millisecond)
+			}
+		})
+	}
+}
+
+func TestLimiterWaitContextCancel(t *testing.T) {
+	l := NewLimiter(Rate{RequestsPerSecond: 1, Burst: 1})
+	require.NoError(t, l.Wait(context.Background(), "a", "/swap/"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := l.Wait(ctx, "a", "/swap/")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// The abandoned reservation is returned, so the next caller is not queued behind it
+	b := l.buckets[bucketKey{apiKey: "a"}]
+	assert.InDelta(t, 0, b.tokens, 0.1)
+}
+
+func TestLimiterSetFamilyRateAfterUse(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewLimiter(Rate{}).SetFamilyRate("/fusion/", Rate{RequestsPerSecond: 1, Burst: 1})
+	l.now = func() time.Time { return start }
+	require.NoError(t, l.Wait(context.Background(), "a", "/fusion/quoter"))
+
+	// The bucket already exists, and the old rate would make the next request wait a second
+	l.SetFamilyRate("/fusion/", Rate{RequestsPerSecond: 1000, Burst: 1})
+	b := l.buckets[bucketKey{apiKey: "a", family: "/fusion/"}]
+	assert.Equal(t, Rate{RequestsPerSecond: 1000, Burst: 1}, b.rate)
+	assert.Equal(t, time.Millisecond, b.reserve(start))
+}
@@ -0,0 +1,119 @@
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+// scrubbedHeaders are removed from recorded requests because they carry credentials.
+var scrubbedHeaders = []string{"Authorization", "Cookie"}
+
+// Cassette is the on-disk format of a recording: the interactions in the order they happened.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a single request and the response the API returned for it.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request. Credentials are scrubbed from Header.
+type Request struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Query  string      `json:"query,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// key identifies the interactions that can answer a request: method, path, query with its
+// parameters sorted, and body with its JSON object keys sorted and whitespace removed.
+func (r Request) key() string {
+	return r.Method + " " + r.Path + "?" + normalizeQuery(r.Query) + " " + normalizeBody(r.Body)
+}
+
+func normalizeQuery(rawQuery string) string {
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return rawQuery
+	}
+	// Encode sorts by key
+	return values.Encode()
+}
+
+func normalizeBody(body string) string {
+	var decoded any
+	if err := json.Unmarshal([]byte(body), &decoded); err != nil {
+		return body
+	}
+	// map keys are marshaled in sorted order
+	normalized, err := json.Marshal(decoded)
+	if err != nil {
+		return body
+	}
+	return string(normalized)
+}
+
+// LoadCassette reads the cassette at path.
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+	var c Cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to decode cassette %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// Save writes the cassette to path, creating parent directories as needed.
+func (c *Cassette) Save(path string) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetIndent("", "  ")
+	// keep response bodies readable in diffs
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(c); err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// newRequest records req, whose body has already been read into body.
+func newRequest(req *http.Request, body []byte) Request {
+	header := req.Header.Clone()
+	for _, h := range scrubbedHeaders {
+		header.Del(h)
+	}
+	return Request{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  req.URL.RawQuery,
+		Header: header,
+		Body:   string(body),
+	}
+}
+
+var errEmptyCassette = errors.New("cassette has no interactions")
@@ -0,0 +1,202 @@
+// Package replay records 1inch API interactions to cassette files and replays them offline,
+// for deterministic tests of flows built on the SDK clients without network access.
+//
+// Record once against the live API, commit the cassette, and replay it in CI:
+//
+//	mode := replay.ModeReplay
+//	if os.Getenv("RECORD") != "" {
+//		mode = replay.ModeRecord
+//	}
+//	recorder, err := replay.New("testdata/quote.json", mode, "https://api.1inch.dev", os.Getenv("DEV_PORTAL_TOKEN"))
+//	...
+//	config, err := aggregation.NewConfiguration(aggregation.ConfigurationParams{
+//		...
+//		HttpOptions: []common.HttpOption{common.WithExecutor(recorder)},
+//	})
+//
+// Requests are matched by method, path, query and body. Query parameter order and JSON body
+// key order and formatting do not affect matching. Identical requests are answered in
+// recording order, and the last matching response is repeated once they run out, which
+// suits polling loops. Requests with no recording fail with an error wrapping
+// ErrNoInteraction. Authorization headers are never written to cassettes.
+package replay
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+	http_executor "github.com/1inch/1inch-sdk-go/v4/internal/http-executor"
+)
+
+// ErrNoInteraction is returned in replay mode for requests that the cassette has no recording of.
+var ErrNoInteraction = errors.New("replay: no recorded interaction matches the request")
+
+// Mode selects whether a Recorder talks to the API or to its cassette.
+type Mode int
+
+const (
+	// ModeReplay answers requests from the cassette and never uses the network.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests to the API and writes every interaction to the cassette,
+	// replacing its previous contents.
+	ModeRecord
+)
+
+// Recorder is a common.HttpExecutor that records API interactions to a cassette file or
+// replays them from it. It is safe for concurrent use.
+type Recorder struct {
+	path     string
+	mode     Mode
+	executor common.HttpExecutor
+
+	mu       sync.Mutex
+	cassette *Cassette
+	// next holds, per request key, the index into matches of the next interaction to replay
+	next    map[string]int
+	matches map[string][]int
+}
+
+// New returns a recorder for the cassette at path. apiUrl and apiKey are used to reach the
+// API in record mode; in replay mode they are ignored apart from the URL path prefix.
+// opts configure the SDK executor that does the actual work, so options such as retries
+// behave the same when recording and replaying. In replay mode the cassette must exist.
+func New(path string, mode Mode, apiUrl string, apiKey string, opts ...common.HttpOption) (*Recorder, error) {
+	r := &Recorder{
+		path: path,
+		mode: mode,
+		next: make(map[string]int),
+	}
+
+	var transport common.HttpOption
+	switch mode {
+	case ModeRecord:
+		r.cassette = &Cassette{}
+		if err := r.cassette.Save(path); err != nil {
+			return nil, err
+		}
+		// innermost, so the recording holds exactly what went over the wire
+		transport = common.WithMiddleware(r.record)
+	case ModeReplay:
+		cassette, err := LoadCassette(path)
+		if err != nil {
+			return nil, err
+		}
+		if len(cassette.Interactions) == 0 {
+			return nil, fmt.Errorf("failed to load %s: %w", path, errEmptyCassette)
+		}
+		r.cassette = cassette
+		r.matches = make(map[string][]int)
+		for i, interaction := range cassette.Interactions {
+			key := interaction.Request.key()
+			r.matches[key] = append(r.matches[key], i)
+		}
+		transport = common.WithTransport(common.RoundTripperFunc(r.replay))
+	default:
+		return nil, fmt.Errorf("unknown replay mode %d", mode)
+	}
+
+	executor, err := http_executor.DefaultHttpClient(apiUrl, apiKey, append(opts, transport)...)
+	if err != nil {
+		return nil, err
+	}
+	r.executor = executor
+	return r, nil
+}
+
+// ExecuteRequest implements common.HttpExecutor.
+func (r *Recorder) ExecuteRequest(ctx context.Context, payload common.RequestPayload, v any) error {
+	return r.executor.ExecuteRequest(ctx, payload, v)
+}
+
+// Cassette returns a copy of the interactions recorded or loaded so far.
+func (r *Recorder) Cassette() Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return Cassette{Interactions: append([]Interaction(nil), r.cassette.Interactions...)}
+}
+
+// record is the middleware that captures interactions in record mode. The cassette is
+// saved after every interaction, so a test that fails halfway still leaves a usable file.
+func (r *Recorder) record(next http.RoundTripper) http.RoundTripper {
+	return common.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		reqBody, err := readBody(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+
+		resp, err := next.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		respBody, err := readBody(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+			Request: newRequest(req, reqBody),
+			Response: Response{
+				StatusCode: resp.StatusCode,
+				Header:     resp.Header.Clone(),
+				Body:       string(respBody),
+			},
+		})
+		if err := r.cassette.Save(r.path); err != nil {
+			return nil, err
+		}
+		return resp, nil
+	})
+}
+
+// replay is the transport that answers requests from the cassette in replay mode.
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	key := newRequest(req, body).key()
+
+	r.mu.Lock()
+	matches := r.matches[key]
+	if len(matches) == 0 {
+		r.mu.Unlock()
+		return nil, fmt.Errorf("%w in %s: %s %s", ErrNoInteraction, r.path, req.Method, req.URL.RequestURI())
+	}
+	n := r.next[key]
+	if n < len(matches)-1 {
+		r.next[key] = n + 1
+	}
+	recorded := r.cassette.Interactions[matches[n]].Response
+	r.mu.Unlock()
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recorded.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader([]byte(recorded.Body))),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}, nil
+}
+
+func readBody(body io.ReadCloser) ([]byte, error) {
+	if body == nil || body == http.NoBody {
+		return nil, nil
+	}
+	defer body.Close()
+	return io.ReadAll(body)
+}
@@ -0,0 +1,201 @@
+package replay
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+)
+
+type quoteParams struct {
+	Src    string `url:"src"`
+	Dst    string `url:"dst"`
+	Amount string `url:"amount"`
+}
+
+// reorderedQuoteParams encodes the same query as quoteParams with the parameters in another order
+type reorderedQuoteParams struct {
+	Amount string `url:"amount"`
+	Dst    string `url:"dst"`
+	Src    string `url:"src"`
+}
+
+func newTestAPI(t *testing.T) *httptest.Server {
+	t.Helper()
+	statusCalls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/swap/v6.0/1/quote":
+			_, _ = io.WriteString(w, `{"dstAmount":"`+r.URL.Query().Get("amount")+`0"}`)
+		case "/fusion/relayer/v2.0/1/order/submit":
+			body, _ := io.ReadAll(r.Body)
+			_, _ = w.Write(body)
+		case "/fusion/orders/v2.0/1/order/status/0x01":
+			statusCalls++
+			if statusCalls == 1 {
+				_, _ = io.WriteString(w, `{"status":"pending"}`)
+				return
+			}
+			_, _ = io.WriteString(w, `{"status":"filled"}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"error":"Bad Request","description":"insufficient liquidity"}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	server := newTestAPI(t)
+	path := filepath.Join(t.TempDir(), "cassettes", "flow.json")
+	ctx := context.Background()
+
+	recorder, err := New(path, ModeRecord, server.URL, "secretApiKey")
+	require.NoError(t, err)
+
+	var quote map[string]string
+	require.NoError(t, recorder.ExecuteRequest(ctx, common.RequestPayload{
+		Method: "GET", U: "/swap/v6.0/1/quote", Params: quoteParams{Src: "0xa", Dst: "0xb", Amount: "100"},
+	}, &quote))
+	require.NoError(t, recorder.ExecuteRequest(ctx, common.RequestPayload{
+		Method: "POST", U: "/fusion/relayer/v2.0/1/order/submit", Body: []byte(`{"quoteId":"q1","signature":"0xsig"}`),
+	}, nil))
+	for i := 0; i < 2; i++ {
+		require.NoError(t, recorder.ExecuteRequest(ctx, common.RequestPayload{Method: "GET", U: "/fusion/orders/v2.0/1/order/status/0x01"}, nil))
+	}
+	err = recorder.ExecuteRequest(ctx, common.RequestPayload{Method: "GET", U: "/swap/v6.0/1/unknown"}, nil)
+	require.ErrorIs(t, err, common.ErrInsufficientLiquidity)
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "secretApiKey")
+	assert.NotContains(t, string(raw), "Authorization")
+	require.Len(t, recorder.Cassette().Interactions, 5)
+
+	server.Close()
+	replayer, err := New(path, ModeReplay, "https://api.1inch.dev", "")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		payload     common.RequestPayload
+		expected    map[string]string
+		expectedErr error
+	}{
+		{
+			name:     "Query parameter order does not matter",
+			payload:  common.RequestPayload{Method: "GET", U: "/swap/v6.0/1/quote", Params: reorderedQuoteParams{Amount: "100", Dst: "0xb", Src: "0xa"}},
+			expected: map[string]string{"dstAmount": "1000"},
+		},
+		{
+			name:     "JSON body key order and whitespace do not matter",
+			payload:  common.RequestPayload{Method: "POST", U: "/fusion/relayer/v2.0/1/order/submit", Body: []byte(`{ "signature": "0xsig", "quoteId": "q1" }`)},
+			expected: map[string]string{"quoteId": "q1", "signature": "0xsig"},
+		},
+		{
+			name:     "Repeated requests replay in recording order",
+			payload:  common.RequestPayload{Method: "GET", U: "/fusion/orders/v2.0/1/order/status/0x01"},
+			expected: map[string]string{"status": "pending"},
+		},
+		{
+			name:     "The last response repeats once recordings run out",
+			payload:  common.RequestPayload{Method: "GET", U: "/fusion/orders/v2.0/1/order/status/0x01"},
+			expected: map[string]string{"status": "filled"},
+		},
+		{
+			name:     "The last response keeps repeating",
+			payload:  common.RequestPayload{Method: "GET", U: "/fusion/orders/v2.0/1/order/status/0x01"},
+			expected: map[string]string{"status": "filled"},
+		},
+		{
+			name:        "Recorded API errors replay as API errors",
+			payload:     common.RequestPayload{Method: "GET", U: "/swap/v6.0/1/unknown"},
+			expectedErr: common.ErrInsufficientLiquidity,
+		},
+		{
+			name:        "Different query fails",
+			payload:     common.RequestPayload{Method: "GET", U: "/swap/v6.0/1/quote", Params: quoteParams{Src: "0xa", Dst: "0xb", Amount: "200"}},
+			expectedErr: ErrNoInteraction,
+		},
+		{
+			name:        "Different body fails",
+			payload:     common.RequestPayload{Method: "POST", U: "/fusion/relayer/v2.0/1/order/submit", Body: []byte(`{"quoteId":"q2"}`)},
+			expectedErr: ErrNoInteraction,
+		},
+		{
+			name:        "Different method fails",
+			payload:     common.RequestPayload{Method: "DELETE", U: "/fusion/orders/v2.0/1/order/status/0x01"},
+			expectedErr: ErrNoInteraction,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var result map[string]string
+			err := replayer.ExecuteRequest(ctx, tc.payload, &result)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty.json")
+	require.NoError(t, (&Cassette{}).Save(empty))
+	invalid := filepath.Join(dir, "invalid.json")
+	require.NoError(t, os.WriteFile(invalid, []byte("not json"), 0o644))
+
+	tests := []struct {
+		name          string
+		path          string
+		mode          Mode
+		expectedError string
+	}{
+		{
+			name:          "Replay of a missing cassette",
+			path:          filepath.Join(dir, "missing.json"),
+			mode:          ModeReplay,
+			expectedError: "failed to read cassette",
+		},
+		{
+			name:          "Replay of an empty cassette",
+			path:          empty,
+			mode:          ModeReplay,
+			expectedError: "cassette has no interactions",
+		},
+		{
+			name:          "Replay of an invalid cassette",
+			path:          invalid,
+			mode:          ModeReplay,
+			expectedError: "failed to decode cassette",
+		},
+		{
+			name:          "Unknown mode",
+			path:          empty,
+			mode:          Mode(42),
+			expectedError: "unknown replay mode 42",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := New(tc.path, tc.mode, "https://api.1inch.dev", "")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectedError)
+		})
+	}
+}
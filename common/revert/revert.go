@@ -0,0 +1,197 @@
+// Package revert decodes the revert data of failed calls and transactions into typed errors.
+// It knows the custom errors of the AggregationRouterV6 (which includes the Limit Order
+// Protocol), the Fusion settlement extension and Permit2, plus Solidity's Error(string) and
+// Panic(uint256):
+//
+//	_, err := wallet.Simulate(ctx, msg)
+//	if errors.Is(err, revert.ErrReturnAmountIsNotEnough) {
+//		// quote again with a larger slippage
+//	}
+//	var revertErr *revert.Error
+//	if errors.As(err, &revertErr) {
+//		fmt.Println(revertErr.Contract, revertErr.Name, revertErr.Args)
+//	}
+package revert
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/1inch/1inch-sdk-go/v4/constants"
+)
+
+// Contracts whose custom errors are decoded, as reported in Error.Contract
+const (
+	ContractAggregationRouterV6 = "AggregationRouterV6"
+	ContractFusionSettlement    = "FusionSettlement"
+	ContractPermit2             = "Permit2"
+)
+
+var (
+	// ErrBadSignature means the order or permit signature does not match the maker.
+	ErrBadSignature = sentinel(ContractAggregationRouterV6, "BadSignature")
+	// ErrInvalidatedOrder means the order was cancelled or already filled.
+	ErrInvalidatedOrder = sentinel(ContractAggregationRouterV6, "InvalidatedOrder")
+	// ErrOrderExpired means the order's expiration has passed.
+	ErrOrderExpired = sentinel(ContractAggregationRouterV6, "OrderExpired")
+	// ErrPredicateIsNotTrue means the order's predicate rejected the fill.
+	ErrPredicateIsNotTrue = sentinel(ContractAggregationRouterV6, "PredicateIsNotTrue")
+	// ErrPrivateOrder means the order can only be filled by its allowed sender.
+	ErrPrivateOrder = sentinel(ContractAggregationRouterV6, "PrivateOrder")
+	// ErrReturnAmountIsNotEnough means the swap returned less than its minimum return amount.
+	ErrReturnAmountIsNotEnough = sentinel(ContractAggregationRouterV6, "ReturnAmountIsNotEnough")
+	// ErrTransferFromMakerToTakerFailed means the maker's tokens could not be transferred,
+	// usually for lack of balance or allowance.
+	ErrTransferFromMakerToTakerFailed = sentinel(ContractAggregationRouterV6, "TransferFromMakerToTakerFailed")
+	// ErrTransferFromTakerToMakerFailed means the taker's tokens could not be transferred.
+	ErrTransferFromTakerToMakerFailed = sentinel(ContractAggregationRouterV6, "TransferFromTakerToMakerFailed")
+	// ErrResolverCanNotFillOrder means the resolver is not allowed to fill the Fusion order yet.
+	ErrResolverCanNotFillOrder = sentinel(ContractFusionSettlement, "ResolverCanNotFillOrder")
+	// ErrPermit2InsufficientAllowance means the Permit2 allowance is lower than the amount.
+	ErrPermit2InsufficientAllowance = sentinel(ContractPermit2, "InsufficientAllowance")
+	// ErrPermit2SignatureExpired means the Permit2 signature deadline has passed.
+	ErrPermit2SignatureExpired = sentinel(ContractPermit2, "SignatureExpired")
+)
+
+// Error is a decoded revert. Use errors.Is with the Err variables of this package to check for
+// a specific custom error, or errors.As to read its arguments.
+type Error struct {
+	// Contract is the contract that declares the error, or empty for Error(string),
+	// Panic(uint256) and unknown errors
+	Contract string
+	// Name is the error name: a custom error, "Error", "Panic", or empty when the selector
+	// is unknown
+	Name string
+	// Args are the decoded arguments of the error
+	Args []interface{}
+	// Data is the raw revert data
+	Data []byte
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Name == "Error" && e.Contract == "" && len(e.Args) == 1:
+		return fmt.Sprintf("execution reverted: %v", e.Args[0])
+	case e.Name == "Panic" && e.Contract == "" && len(e.Args) == 1:
+		return fmt.Sprintf("execution reverted: panic 0x%x", e.Args[0])
+	case e.Name == "":
+		if len(e.Data) == 0 {
+			return "execution reverted"
+		}
+		return fmt.Sprintf("execution reverted: unknown error %s", hexutil.Encode(e.Data))
+	}
+	args := make([]string, len(e.Args))
+	for i, arg := range e.Args {
+		args[i] = fmt.Sprint(arg)
+	}
+	return fmt.Sprintf("execution reverted: %s(%s)", e.Name, strings.Join(args, ", "))
+}
+
+// Is reports whether target is the sentinel of the same custom error.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok || t.Data != nil {
+		return false
+	}
+	return t.Name == e.Name && t.Contract == e.Contract
+}
+
+func sentinel(contract string, name string) *Error {
+	return &Error{Contract: contract, Name: name}
+}
+
+type knownError struct {
+	contract string
+	name     string
+	inputs   abi.Arguments
+}
+
+// errorsBySelector maps the 4-byte selector of every known error to its declaration
+var errorsBySelector = mustIndex(map[string]string{
+	ContractAggregationRouterV6: constants.AggregationRouterV6ABI,
+	ContractFusionSettlement:    constants.FusionSettlementErrorsABI,
+	ContractPermit2:             constants.Permit2ErrorsABI,
+})
+
+func mustIndex(abis map[string]string) map[[4]byte]knownError {
+	index := make(map[[4]byte]knownError)
+	for contract, raw := range abis {
+		parsed, err := abi.JSON(strings.NewReader(raw))
+		if err != nil {
+			panic(fmt.Sprintf("invalid %s ABI: %v", contract, err))
+		}
+		for _, e := range parsed.Errors {
+			var selector [4]byte
+			copy(selector[:], e.ID[:4])
+			// Overloaded errors are renamed by the ABI parser, the signature has the declared name
+			name, _, _ := strings.Cut(e.Sig, "(")
+			index[selector] = knownError{contract: contract, name: name, inputs: e.Inputs}
+		}
+	}
+	return index
+}
+
+var (
+	errorSelector = []byte{0x08, 0xc3, 0x79, 0xa0}
+	panicSelector = []byte{0x4e, 0x48, 0x7b, 0x71}
+)
+
+// Decode decodes revert data. Data with an unknown selector, or that does not match the
+// declared arguments, is returned as an Error without a name.
+func Decode(data []byte) *Error {
+	e := &Error{Data: data}
+	if len(data) < 4 {
+		return e
+	}
+	selector, payload := data[:4], data[4:]
+
+	switch {
+	case bytes.Equal(selector, errorSelector):
+		if reason, err := abi.UnpackRevert(data); err == nil {
+			e.Name, e.Args = "Error", []interface{}{reason}
+		}
+		return e
+	case bytes.Equal(selector, panicSelector):
+		if code, err := (abi.Arguments{{Type: uint256Type}}).Unpack(payload); err == nil {
+			e.Name, e.Args = "Panic", code
+		}
+		return e
+	}
+
+	known, ok := errorsBySelector[[4]byte(selector)]
+	if !ok {
+		return e
+	}
+	args, err := known.inputs.Unpack(payload)
+	if err != nil {
+		return e
+	}
+	e.Contract, e.Name, e.Args = known.contract, known.name, args
+	return e
+}
+
+var uint256Type, _ = abi.NewType("uint256", "", nil)
+
+// FromError extracts and decodes the revert data that a node attaches to an eth_call or
+// eth_estimateGas error. It returns false when err carries no revert data.
+func FromError(err error) (*Error, bool) {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return nil, false
+	}
+	hex, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return nil, false
+	}
+	data, decodeErr := hexutil.Decode(hex)
+	if decodeErr != nil {
+		return nil, false
+	}
+	return Decode(data), true
+}
@@ -0,0 +1,131 @@
+package revert
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name             string
+		data             string
+		expectedContract string
+		expectedName     string
+		expectedArgs     []interface{}
+		expectedError    string
+		expectedIs       error
+	}{
+		{
+			name:             "Router custom error with arguments",
+			data:             "0x064a4ec6" + word(100) + word(250),
+			expectedContract: ContractAggregationRouterV6,
+			expectedName:     "ReturnAmountIsNotEnough",
+			expectedArgs:     []interface{}{big.NewInt(100), big.NewInt(250)},
+			expectedError:    "execution reverted: ReturnAmountIsNotEnough(100, 250)",
+			expectedIs:       ErrReturnAmountIsNotEnough,
+		},
+		{
+			name:             "Limit order error",
+			data:             "0x70a03f48",
+			expectedContract: ContractAggregationRouterV6,
+			expectedName:     "TransferFromMakerToTakerFailed",
+			expectedArgs:     []interface{}{},
+			expectedError:    "execution reverted: TransferFromMakerToTakerFailed()",
+			expectedIs:       ErrTransferFromMakerToTakerFailed,
+		},
+		{
+			name:             "Fusion settlement error",
+			data:             "0xf25114a6",
+			expectedContract: ContractFusionSettlement,
+			expectedName:     "ResolverCanNotFillOrder",
+			expectedArgs:     []interface{}{},
+			expectedError:    "execution reverted: ResolverCanNotFillOrder()",
+			expectedIs:       ErrResolverCanNotFillOrder,
+		},
+		{
+			name:             "Permit2 error",
+			data:             "0xf96fb071" + word(5),
+			expectedContract: ContractPermit2,
+			expectedName:     "InsufficientAllowance",
+			expectedArgs:     []interface{}{big.NewInt(5)},
+			expectedError:    "execution reverted: InsufficientAllowance(5)",
+			expectedIs:       ErrPermit2InsufficientAllowance,
+		},
+		{
+			name: "Revert reason",
+			data: "0x08c379a0" + word(32) + word(11) +
+				"6e6f74206120746f6b656e000000000000000000000000000000000000000000",
+			expectedName:  "Error",
+			expectedArgs:  []interface{}{"not a token"},
+			expectedError: "execution reverted: not a token",
+		},
+		{
+			name:          "Panic",
+			data:          "0x4e487b71" + word(0x11),
+			expectedName:  "Panic",
+			expectedArgs:  []interface{}{big.NewInt(0x11)},
+			expectedError: "execution reverted: panic 0x11",
+		},
+		{
+			name:          "Unknown selector",
+			data:          "0x12345678",
+			expectedError: "execution reverted: unknown error 0x12345678",
+		},
+		{
+			name:          "Arguments that do not match",
+			data:          "0x064a4ec6" + word(100),
+			expectedError: "execution reverted: unknown error 0x064a4ec6" + word(100),
+		},
+		{
+			name:          "Empty",
+			data:          "0x",
+			expectedError: "execution reverted",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			decoded := Decode(gethCommon.FromHex(tc.data))
+			assert.Equal(t, tc.expectedContract, decoded.Contract)
+			assert.Equal(t, tc.expectedName, decoded.Name)
+			if tc.expectedArgs != nil {
+				assert.Equal(t, fmt.Sprint(tc.expectedArgs), fmt.Sprint(decoded.Args))
+			}
+			assert.EqualError(t, decoded, tc.expectedError)
+			if tc.expectedIs != nil {
+				assert.ErrorIs(t, fmt.Errorf("simulation failed: %w", decoded), tc.expectedIs)
+			}
+			assert.NotErrorIs(t, decoded, ErrBadSignature)
+		})
+	}
+}
+
+// dataError mimics the JSON-RPC errors of go-ethereum's rpc package
+type dataError struct {
+	data interface{}
+}
+
+func (e dataError) Error() string          { return "execution reverted" }
+func (e dataError) ErrorData() interface{} { return e.data }
+
+func TestFromError(t *testing.T) {
+	decoded, ok := FromError(fmt.Errorf("failed to estimate gas: %w", dataError{data: "0x5cd5d233"}))
+	require.True(t, ok)
+	assert.ErrorIs(t, decoded, ErrBadSignature)
+
+	_, ok = FromError(dataError{data: 42})
+	assert.False(t, ok)
+	_, ok = FromError(errors.New("connection refused"))
+	assert.False(t, ok)
+}
+
+// word encodes n as a 32-byte ABI word without the 0x prefix
+func word(n int64) string {
+	return fmt.Sprintf("%064x", n)
+}
@@ -0,0 +1,34 @@
+package common
+
+import (
+	"context"
+	"math/big"
+
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Signer produces the signatures of a Wallet, so that the key material can live outside the
+// process (a remote signer, an HSM) instead of in the SDK configuration. The common/signer
+// package provides in-memory and remote JSON-RPC implementations.
+//
+// Signatures are 65 bytes in [R || S || V] form with V as 0 or 1, as returned by crypto.Sign.
+type Signer interface {
+	// Address is the account the signer signs for.
+	Address() gethCommon.Address
+	// SignHash signs a 32-byte digest as is, without any prefix.
+	SignHash(ctx context.Context, hash []byte) ([]byte, error)
+	// SignTypedData signs the EIP-712 digest of typedData.
+	SignTypedData(ctx context.Context, typedData apitypes.TypedData) ([]byte, error)
+	// SignTransaction returns tx signed for chainId with the latest signer the chain supports.
+	SignTransaction(ctx context.Context, tx *types.Transaction, chainId *big.Int) (*types.Transaction, error)
+}
+
+// WithSigner makes the wallet sign with signer instead of a private key. The configuration's
+// PrivateKey must then be left empty.
+func WithSigner(signer Signer) WalletOption {
+	return func(cfg *WalletConfig) {
+		cfg.Signer = signer
+	}
+}
@@ -0,0 +1,28 @@
+package signer
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+)
+
+// NewPrivateKeyFromKeystore decrypts a go-ethereum V3 keystore with passphrase and returns a
+// signer for its key.
+func NewPrivateKeyFromKeystore(keyJSON []byte, passphrase string) (*PrivateKey, error) {
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore: %w", err)
+	}
+	return NewPrivateKey(key.PrivateKey), nil
+}
+
+// NewPrivateKeyFromKeystoreFile reads the V3 keystore file at path, as written by geth and
+// Clef, decrypts it with passphrase and returns a signer for its key.
+func NewPrivateKeyFromKeystoreFile(path string, passphrase string) (*PrivateKey, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore: %w", err)
+	}
+	return NewPrivateKeyFromKeystore(keyJSON, passphrase)
+}
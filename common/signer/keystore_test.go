@@ -0,0 +1,66 @@
+package signer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPrivateKeyFromKeystoreFile(t *testing.T) {
+	privateKey, err := crypto.HexToECDSA(testPrivateKey)
+	require.NoError(t, err)
+	keyJSON, err := keystore.EncryptKey(&keystore.Key{
+		Id:         uuid.New(),
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}, "correct horse", keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "keystore.json")
+	require.NoError(t, os.WriteFile(path, keyJSON, 0o600))
+
+	tests := []struct {
+		name          string
+		path          string
+		passphrase    string
+		expectedError string
+	}{
+		{
+			name:       "Valid keystore",
+			path:       path,
+			passphrase: "correct horse",
+		},
+		{
+			name:          "Wrong passphrase",
+			path:          path,
+			passphrase:    "battery staple",
+			expectedError: "failed to decrypt keystore: could not decrypt key with given password",
+		},
+		{
+			name:          "Missing file",
+			path:          filepath.Join(dir, "missing.json"),
+			expectedError: "failed to read keystore",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := NewPrivateKeyFromKeystoreFile(tc.path, tc.passphrase)
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, gethCommon.HexToAddress(testAddress), s.Address())
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// DefaultDerivationPath is the BIP-44 path of the first Ethereum account, as used by
+// MetaMask, Ledger Live and most other wallets.
+const DefaultDerivationPath = "m/44'/60'/0'/0/0"
+
+// NewPrivateKeyFromMnemonic derives the key at the BIP-32 path of a BIP-39 mnemonic, such as
+// DefaultDerivationPath. passphrase is the optional BIP-39 passphrase; leave it empty when
+// the mnemonic has none.
+func NewPrivateKeyFromMnemonic(mnemonic string, passphrase string, path string) (*PrivateKey, error) {
+	keys, err := NewPrivateKeysFromMnemonic(mnemonic, passphrase, path, 1)
+	if err != nil {
+		return nil, err
+	}
+	return keys[0], nil
+}
+
+// NewPrivateKeysFromMnemonic derives n consecutive accounts of a BIP-39 mnemonic, starting
+// at basePath and incrementing its last component: from DefaultDerivationPath it returns the
+// accounts at m/44'/60'/0'/0/0 to m/44'/60'/0'/0/n-1.
+func NewPrivateKeysFromMnemonic(mnemonic string, passphrase string, basePath string, n int) ([]*PrivateKey, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("account count must be positive, got %d", n)
+	}
+	path, err := accounts.ParseDerivationPath(basePath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid derivation path: %w", err)
+	}
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mnemonic: %w", err)
+	}
+	defer clear(seed)
+
+	next := accounts.DefaultIterator(path)
+	keys := make([]*PrivateKey, 0, n)
+	for i := 0; i < n; i++ {
+		accountPath := next()
+		key, err := deriveKey(seed, accountPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive %s: %w", accountPath, err)
+		}
+		keys = append(keys, NewPrivateKey(key))
+	}
+	return keys, nil
+}
+
+// deriveKey derives the BIP-32 private key at path from seed
+func deriveKey(seed []byte, path accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	curveOrder := crypto.S256().Params().N
+
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	key, chainCode := new(big.Int).SetBytes(sum[:32]), sum[32:]
+	if key.Sign() == 0 || key.Cmp(curveOrder) >= 0 {
+		return nil, fmt.Errorf("seed produces an invalid master key")
+	}
+
+	for _, index := range path {
+		var data []byte
+		if index >= 0x80000000 {
+			data = append([]byte{0}, key.FillBytes(make([]byte, 32))...)
+		} else {
+			privateKey, err := crypto.ToECDSA(key.FillBytes(make([]byte, 32)))
+			if err != nil {
+				return nil, err
+			}
+			data = crypto.CompressPubkey(&privateKey.PublicKey)
+		}
+		data = binary.BigEndian.AppendUint32(data, index)
+
+		mac = hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		sum = mac.Sum(nil)
+		tweak := new(big.Int).SetBytes(sum[:32])
+		if tweak.Cmp(curveOrder) >= 0 {
+			return nil, fmt.Errorf("index %d produces an invalid key", index)
+		}
+		key.Add(key, tweak).Mod(key, curveOrder)
+		if key.Sign() == 0 {
+			return nil, fmt.Errorf("index %d produces an invalid key", index)
+		}
+		chainCode = sum[32:]
+	}
+	return crypto.ToECDSA(key.FillBytes(make([]byte, 32)))
+}
@@ -0,0 +1,90 @@
+package signer
+
+import (
+	"testing"
+
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testMnemonic is the well-known development mnemonic of Hardhat and Anvil
+const testMnemonic = "test test test test test test test test test test test junk"
+
+func TestNewPrivateKeysFromMnemonic(t *testing.T) {
+	tests := []struct {
+		name          string
+		mnemonic      string
+		passphrase    string
+		basePath      string
+		n             int
+		expected      []string
+		expectedError string
+	}{
+		{
+			name:     "First accounts of the default path",
+			mnemonic: testMnemonic,
+			basePath: DefaultDerivationPath,
+			n:        3,
+			expected: []string{
+				"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+				"0x70997970C51812dc3A010C7d01b50e0d17dc79C8",
+				"0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC",
+			},
+		},
+		{
+			name:     "Enumeration starts at the base path",
+			mnemonic: testMnemonic,
+			basePath: "m/44'/60'/0'/0/1",
+			n:        1,
+			expected: []string{"0x70997970C51812dc3A010C7d01b50e0d17dc79C8"},
+		},
+		{
+			name:          "Bad checksum",
+			mnemonic:      "test test test test test test test test test test test test",
+			basePath:      DefaultDerivationPath,
+			n:             1,
+			expectedError: "invalid mnemonic",
+		},
+		{
+			name:          "Invalid path",
+			mnemonic:      testMnemonic,
+			basePath:      "m/44'/60'/x",
+			n:             1,
+			expectedError: "invalid derivation path",
+		},
+		{
+			name:          "No accounts",
+			mnemonic:      testMnemonic,
+			basePath:      DefaultDerivationPath,
+			n:             0,
+			expectedError: "account count must be positive, got 0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			keys, err := NewPrivateKeysFromMnemonic(tc.mnemonic, tc.passphrase, tc.basePath, tc.n)
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, keys, len(tc.expected))
+			for i, key := range keys {
+				assert.Equal(t, gethCommon.HexToAddress(tc.expected[i]), key.Address())
+			}
+		})
+	}
+}
+
+func TestNewPrivateKeyFromMnemonicPassphrase(t *testing.T) {
+	withoutPassphrase, err := NewPrivateKeyFromMnemonic(testMnemonic, "", DefaultDerivationPath)
+	require.NoError(t, err)
+	withPassphrase, err := NewPrivateKeyFromMnemonic(testMnemonic, "passphrase", DefaultDerivationPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, gethCommon.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"), withoutPassphrase.Address())
+	assert.NotEqual(t, withoutPassphrase.Address(), withPassphrase.Address())
+}
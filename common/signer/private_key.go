@@ -0,0 +1,83 @@
+// Package signer provides implementations of common.Signer: PrivateKey signs with a key held
+// in memory, loaded from hex, a V3 keystore file or a BIP-39 mnemonic, and Remote delegates
+// signing to a Web3Signer or Clef instance over JSON-RPC so that the key never enters the
+// process:
+//
+//	s, err := signer.NewRemote(signer.RemoteParams{
+//		URL:      "http://localhost:9000",
+//		Address:  gethCommon.HexToAddress("0x..."),
+//		Protocol: signer.ProtocolWeb3Signer,
+//	})
+//	...
+//	config, err := orderbook.NewConfiguration(orderbook.ConfigurationParams{
+//		NodeUrl:       nodeUrl,
+//		ChainId:       constants.EthereumChainId,
+//		ApiUrl:        "https://api.1inch.dev",
+//		ApiKey:        apiKey,
+//		WalletOptions: []common.WalletOption{common.WithSigner(s)},
+//	})
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+)
+
+var _ common.Signer = (*PrivateKey)(nil)
+
+// PrivateKey signs with an ECDSA private key held in memory.
+type PrivateKey struct {
+	key     *ecdsa.PrivateKey
+	address gethCommon.Address
+}
+
+// NewPrivateKey returns a signer for key.
+func NewPrivateKey(key *ecdsa.PrivateKey) *PrivateKey {
+	return &PrivateKey{
+		key:     key,
+		address: crypto.PubkeyToAddress(key.PublicKey),
+	}
+}
+
+// NewPrivateKeyFromHex parses a hex-encoded private key, without the 0x prefix, and returns
+// a signer for it.
+func NewPrivateKeyFromHex(hexKey string) (*PrivateKey, error) {
+	key, err := crypto.HexToECDSA(hexKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize private key: %w", err)
+	}
+	return NewPrivateKey(key), nil
+}
+
+func (s *PrivateKey) Address() gethCommon.Address {
+	return s.address
+}
+
+func (s *PrivateKey) SignHash(_ context.Context, hash []byte) ([]byte, error) {
+	signature, err := crypto.Sign(hash, s.key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign hash: %w", err)
+	}
+	return signature, nil
+}
+
+func (s *PrivateKey) SignTypedData(ctx context.Context, typedData apitypes.TypedData) ([]byte, error) {
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute typed data hash: %w", err)
+	}
+	return s.SignHash(ctx, hash)
+}
+
+func (s *PrivateKey) SignTransaction(_ context.Context, tx *types.Transaction, chainId *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainId), s.key)
+}
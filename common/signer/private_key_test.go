@@ -0,0 +1,121 @@
+package signer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testPrivateKey = "965e092fdfc08940d2bd05c7b5c7e1c51e283e92c7f52bbf1408973ae9a9acb7"
+	testAddress    = "0x2c9b2DBdbA8A9c969Ac24153f5C1c23CB0e63914"
+)
+
+func testTypedData() apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"Permit": {
+				{Name: "owner", Type: "address"},
+				{Name: "value", Type: "uint256"},
+				{Name: "salt", Type: "bytes32"},
+			},
+		},
+		PrimaryType: "Permit",
+		Domain: apitypes.TypedDataDomain{
+			Name:              "Token",
+			ChainId:           math.NewHexOrDecimal256(1),
+			VerifyingContract: "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48",
+		},
+		Message: apitypes.TypedDataMessage{
+			"owner": testAddress,
+			"value": new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1)),
+			"salt":  gethCommon.HexToHash("0x01").Bytes(),
+		},
+	}
+}
+
+func testTransactions(chainId *big.Int) map[string]*types.Transaction {
+	to := gethCommon.HexToAddress("0x111111125421ca6dc452d289314280a0f8842a65")
+	return map[string]*types.Transaction{
+		"Legacy": types.NewTx(&types.LegacyTx{
+			Nonce: 1, GasPrice: big.NewInt(20_000_000_000), Gas: 21000, To: &to, Value: big.NewInt(1),
+		}),
+		"Access list": types.NewTx(&types.AccessListTx{
+			ChainID: chainId, Nonce: 2, GasPrice: big.NewInt(20_000_000_000), Gas: 50000, To: &to, Data: []byte{0x07, 0xed},
+			AccessList: types.AccessList{{Address: to, StorageKeys: []gethCommon.Hash{{0x01}}}},
+		}),
+		"Dynamic fee": types.NewTx(&types.DynamicFeeTx{
+			ChainID: chainId, Nonce: 3, GasTipCap: big.NewInt(1_000_000_000), GasFeeCap: big.NewInt(30_000_000_000), Gas: 180000, To: &to, Data: []byte{0x07, 0xed},
+		}),
+	}
+}
+
+func TestPrivateKey(t *testing.T) {
+	s, err := NewPrivateKeyFromHex(testPrivateKey)
+	require.NoError(t, err)
+	assert.Equal(t, gethCommon.HexToAddress(testAddress), s.Address())
+	ctx := context.Background()
+
+	t.Run("SignHash", func(t *testing.T) {
+		hash := crypto.Keccak256([]byte("message"))
+		signature, err := s.SignHash(ctx, hash)
+		require.NoError(t, err)
+		require.Len(t, signature, crypto.SignatureLength)
+		publicKey, err := crypto.SigToPub(hash, signature)
+		require.NoError(t, err)
+		assert.Equal(t, s.Address(), crypto.PubkeyToAddress(*publicKey))
+	})
+
+	t.Run("SignTypedData", func(t *testing.T) {
+		hash, _, err := apitypes.TypedDataAndHash(testTypedData())
+		require.NoError(t, err)
+		signature, err := s.SignTypedData(ctx, testTypedData())
+		require.NoError(t, err)
+		publicKey, err := crypto.SigToPub(hash, signature)
+		require.NoError(t, err)
+		assert.Equal(t, s.Address(), crypto.PubkeyToAddress(*publicKey))
+	})
+
+	chainId := big.NewInt(1)
+	for name, tx := range testTransactions(chainId) {
+		t.Run("SignTransaction "+name, func(t *testing.T) {
+			signedTx, err := s.SignTransaction(ctx, tx, chainId)
+			require.NoError(t, err)
+			sender, err := types.Sender(types.LatestSignerForChainID(chainId), signedTx)
+			require.NoError(t, err)
+			assert.Equal(t, s.Address(), sender)
+		})
+	}
+}
+
+func TestNewPrivateKeyFromHex(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+	}{
+		{name: "Blank", key: ""},
+		{name: "Bad characters", key: "malformed_private_key"},
+		{name: "0x prefix", key: "0x" + testPrivateKey},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewPrivateKeyFromHex(tc.key)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "failed to initialize private key")
+		})
+	}
+}
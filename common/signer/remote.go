@@ -0,0 +1,270 @@
+package signer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+)
+
+var _ common.Signer = (*Remote)(nil)
+
+// Protocol selects the JSON-RPC dialect of a remote signer.
+type Protocol int
+
+const (
+	// ProtocolWeb3Signer speaks the eth1 JSON-RPC API of Web3Signer: eth_signTransaction and
+	// eth_signTypedData.
+	ProtocolWeb3Signer Protocol = iota
+	// ProtocolClef speaks the external API of Clef: account_signTransaction and account_signTypedData.
+	ProtocolClef
+)
+
+type protocolMethods struct {
+	signTransaction string
+	signTypedData   string
+	// sendChainId is set when the signer expects the chain ID in the transaction arguments
+	// rather than taking it from its own configuration
+	sendChainId bool
+}
+
+var methods = map[Protocol]protocolMethods{
+	ProtocolWeb3Signer: {signTransaction: "eth_signTransaction", signTypedData: "eth_signTypedData"},
+	ProtocolClef:       {signTransaction: "account_signTransaction", signTypedData: "account_signTypedData", sendChainId: true},
+}
+
+// RemoteParams configures a remote signer.
+type RemoteParams struct {
+	// URL is the JSON-RPC endpoint of the signer
+	URL string
+	// Address is the account to sign with; the signer must hold its key
+	Address gethCommon.Address
+	// Protocol is the JSON-RPC dialect of the signer. The zero value is ProtocolWeb3Signer.
+	Protocol Protocol
+	// HttpClient sends the requests. Nil uses a default client.
+	HttpClient *http.Client
+	// Header is added to every request, for example to authenticate with the signer
+	Header http.Header
+}
+
+// Remote delegates signing to a Web3Signer or Clef instance over JSON-RPC. Every signature it
+// receives is checked against Address, and every signed transaction against the transaction
+// that was sent, so a misbehaving signer cannot make the wallet use someone else's signature
+// or a different transaction.
+//
+// Neither protocol signs a raw digest, so SignHash always fails with errors.ErrUnsupported;
+// sign typed data instead.
+type Remote struct {
+	client  *rpc.Client
+	address gethCommon.Address
+	methods protocolMethods
+}
+
+// NewRemote returns a signer for the account params.Address held by the signer at params.URL.
+// No request is sent until the first signature.
+func NewRemote(params RemoteParams) (*Remote, error) {
+	if params.URL == "" {
+		return nil, fmt.Errorf("remote signer url is required")
+	}
+	if params.Address == (gethCommon.Address{}) {
+		return nil, fmt.Errorf("remote signer address is required")
+	}
+	m, ok := methods[params.Protocol]
+	if !ok {
+		return nil, fmt.Errorf("unknown remote signer protocol %d", params.Protocol)
+	}
+
+	opts := []rpc.ClientOption{rpc.WithHeaders(params.Header)}
+	if params.HttpClient != nil {
+		opts = append(opts, rpc.WithHTTPClient(params.HttpClient))
+	}
+	client, err := rpc.DialOptions(context.Background(), params.URL, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create remote signer client: %w", err)
+	}
+
+	return &Remote{
+		client:  client,
+		address: params.Address,
+		methods: m,
+	}, nil
+}
+
+// Close releases the connection to the signer.
+func (r *Remote) Close() {
+	r.client.Close()
+}
+
+func (r *Remote) Address() gethCommon.Address {
+	return r.address
+}
+
+func (r *Remote) SignHash(_ context.Context, _ []byte) ([]byte, error) {
+	return nil, fmt.Errorf("remote signer cannot sign a raw hash: %w", errors.ErrUnsupported)
+}
+
+func (r *Remote) SignTypedData(ctx context.Context, typedData apitypes.TypedData) ([]byte, error) {
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute typed data hash: %w", err)
+	}
+
+	var signature hexutil.Bytes
+	err = r.client.CallContext(ctx, &signature, r.methods.signTypedData, r.address, jsonTypedData(typedData))
+	if err != nil {
+		return nil, fmt.Errorf("remote signer failed to sign typed data: %w", err)
+	}
+	if len(signature) != crypto.SignatureLength {
+		return nil, fmt.Errorf("remote signer returned a %d-byte signature", len(signature))
+	}
+	if signature[crypto.RecoveryIDOffset] >= 27 {
+		signature[crypto.RecoveryIDOffset] -= 27
+	}
+
+	publicKey, err := crypto.SigToPub(hash, signature)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer returned an invalid signature: %w", err)
+	}
+	if signer := crypto.PubkeyToAddress(*publicKey); signer != r.address {
+		return nil, fmt.Errorf("remote signer signed with %s instead of %s", signer.Hex(), r.address.Hex())
+	}
+	return signature, nil
+}
+
+func (r *Remote) SignTransaction(ctx context.Context, tx *types.Transaction, chainId *big.Int) (*types.Transaction, error) {
+	args, err := r.transactionArgs(tx, chainId)
+	if err != nil {
+		return nil, err
+	}
+
+	var result json.RawMessage
+	if err := r.client.CallContext(ctx, &result, r.methods.signTransaction, args); err != nil {
+		return nil, fmt.Errorf("remote signer failed to sign transaction: %w", err)
+	}
+	raw, err := decodeSignedTransaction(result)
+	if err != nil {
+		return nil, err
+	}
+
+	signedTx := new(types.Transaction)
+	if err := signedTx.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("remote signer returned an invalid transaction: %w", err)
+	}
+
+	signer := types.LatestSignerForChainID(chainId)
+	if signer.Hash(signedTx) != signer.Hash(tx) {
+		return nil, fmt.Errorf("remote signer returned a different transaction than the one sent")
+	}
+	sender, err := types.Sender(signer, signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer returned an invalid signature: %w", err)
+	}
+	if sender != r.address {
+		return nil, fmt.Errorf("remote signer signed with %s instead of %s", sender.Hex(), r.address.Hex())
+	}
+	return signedTx, nil
+}
+
+// transactionArgs are the transaction fields in the form both Web3Signer and Clef accept
+type transactionArgs struct {
+	From                 gethCommon.Address  `json:"from"`
+	To                   *gethCommon.Address `json:"to,omitempty"`
+	Gas                  hexutil.Uint64      `json:"gas"`
+	GasPrice             *hexutil.Big        `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big        `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big        `json:"maxPriorityFeePerGas,omitempty"`
+	Value                *hexutil.Big        `json:"value"`
+	Nonce                hexutil.Uint64      `json:"nonce"`
+	Data                 hexutil.Bytes       `json:"data"`
+	AccessList           *types.AccessList   `json:"accessList,omitempty"`
+	ChainId              *hexutil.Big        `json:"chainId,omitempty"`
+}
+
+func (r *Remote) transactionArgs(tx *types.Transaction, chainId *big.Int) (transactionArgs, error) {
+	args := transactionArgs{
+		From:  r.address,
+		To:    tx.To(),
+		Gas:   hexutil.Uint64(tx.Gas()),
+		Value: (*hexutil.Big)(tx.Value()),
+		Nonce: hexutil.Uint64(tx.Nonce()),
+		Data:  tx.Data(),
+	}
+	if r.methods.sendChainId {
+		args.ChainId = (*hexutil.Big)(chainId)
+	}
+
+	switch tx.Type() {
+	case types.LegacyTxType:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	case types.AccessListTxType:
+		accessList := tx.AccessList()
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+		args.AccessList = &accessList
+	case types.DynamicFeeTxType:
+		accessList := tx.AccessList()
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+		args.AccessList = &accessList
+	default:
+		return transactionArgs{}, fmt.Errorf("remote signer cannot sign transactions of type %d: %w", tx.Type(), errors.ErrUnsupported)
+	}
+	return args, nil
+}
+
+// decodeSignedTransaction accepts both the raw transaction Web3Signer returns and the
+// {"raw": ..., "tx": ...} object Clef returns
+func decodeSignedTransaction(result json.RawMessage) ([]byte, error) {
+	var raw hexutil.Bytes
+	if err := json.Unmarshal(result, &raw); err == nil {
+		return raw, nil
+	}
+	var clefResult struct {
+		Raw hexutil.Bytes `json:"raw"`
+	}
+	if err := json.Unmarshal(result, &clefResult); err != nil || len(clefResult.Raw) == 0 {
+		return nil, fmt.Errorf("remote signer returned an unexpected result: %s", result)
+	}
+	return clefResult.Raw, nil
+}
+
+// jsonTypedData returns typedData with integer and byte values of the message converted to
+// strings. Signers decode JSON numbers as float64, which cannot hold uint256 values, and
+// encoding/json would send []byte as base64.
+func jsonTypedData(typedData apitypes.TypedData) apitypes.TypedData {
+	typedData.Message = jsonValue(typedData.Message).(apitypes.TypedDataMessage)
+	return typedData
+}
+
+func jsonValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		converted := make(map[string]interface{}, len(value))
+		for key, field := range value {
+			converted[key] = jsonValue(field)
+		}
+		return converted
+	case []interface{}:
+		converted := make([]interface{}, len(value))
+		for i, item := range value {
+			converted[i] = jsonValue(item)
+		}
+		return converted
+	case *big.Int:
+		return value.String()
+	case []byte:
+		return hexutil.Encode(value)
+	default:
+		return v
+	}
+}
@@ -0,0 +1,279 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSigner is a local Web3Signer/Clef stand-in that signs with key
+type stubSigner struct {
+	*httptest.Server
+
+	protocol Protocol
+	key      *ecdsa.PrivateKey
+	// tamper alters the transaction before it is signed
+	tamper  bool
+	methods []string
+	headers []http.Header
+}
+
+func newStubSigner(t *testing.T, protocol Protocol, key *ecdsa.PrivateKey) *stubSigner {
+	t.Helper()
+	stub := &stubSigner{protocol: protocol, key: key}
+	stub.Server = httptest.NewServer(http.HandlerFunc(stub.serve))
+	t.Cleanup(stub.Close)
+	return stub
+}
+
+func (s *stubSigner) serve(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.methods = append(s.methods, req.Method)
+	s.headers = append(s.headers, r.Header.Clone())
+
+	result, err := s.handle(req.Method, req.Params)
+	response := map[string]any{"jsonrpc": "2.0", "id": req.ID}
+	if err != nil {
+		response["error"] = map[string]any{"code": -32000, "message": err.Error()}
+	} else {
+		response["result"] = result
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (s *stubSigner) handle(method string, params []json.RawMessage) (any, error) {
+	switch method {
+	case "eth_signTransaction", "account_signTransaction":
+		var args transactionArgs
+		if err := json.Unmarshal(params[0], &args); err != nil {
+			return nil, err
+		}
+		chainId := big.NewInt(1)
+		if args.ChainId != nil {
+			chainId = args.ChainId.ToInt()
+		}
+		if s.tamper {
+			args.Nonce++
+		}
+		signedTx, err := types.SignTx(args.toTransaction(chainId), types.LatestSignerForChainID(chainId), s.key)
+		if err != nil {
+			return nil, err
+		}
+		raw, err := signedTx.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		if s.protocol == ProtocolClef {
+			return map[string]any{"raw": hexutil.Bytes(raw), "tx": signedTx}, nil
+		}
+		return hexutil.Bytes(raw), nil
+	case "eth_signTypedData", "account_signTypedData":
+		var typedData apitypes.TypedData
+		if err := json.Unmarshal(params[1], &typedData); err != nil {
+			return nil, err
+		}
+		hash, _, err := apitypes.TypedDataAndHash(typedData)
+		if err != nil {
+			return nil, err
+		}
+		signature, err := crypto.Sign(hash, s.key)
+		if err != nil {
+			return nil, err
+		}
+		signature[crypto.RecoveryIDOffset] += 27
+		return hexutil.Bytes(signature), nil
+	default:
+		return nil, errors.New("the method " + method + " does not exist/is not available")
+	}
+}
+
+func (args transactionArgs) toTransaction(chainId *big.Int) *types.Transaction {
+	switch {
+	case args.MaxFeePerGas != nil:
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID: chainId, Nonce: uint64(args.Nonce), GasTipCap: args.MaxPriorityFeePerGas.ToInt(), GasFeeCap: args.MaxFeePerGas.ToInt(),
+			Gas: uint64(args.Gas), To: args.To, Value: args.Value.ToInt(), Data: args.Data, AccessList: *args.AccessList,
+		})
+	case args.AccessList != nil:
+		return types.NewTx(&types.AccessListTx{
+			ChainID: chainId, Nonce: uint64(args.Nonce), GasPrice: args.GasPrice.ToInt(),
+			Gas: uint64(args.Gas), To: args.To, Value: args.Value.ToInt(), Data: args.Data, AccessList: *args.AccessList,
+		})
+	default:
+		return types.NewTx(&types.LegacyTx{
+			Nonce: uint64(args.Nonce), GasPrice: args.GasPrice.ToInt(), Gas: uint64(args.Gas), To: args.To, Value: args.Value.ToInt(), Data: args.Data,
+		})
+	}
+}
+
+func TestRemote(t *testing.T) {
+	key, err := crypto.HexToECDSA(testPrivateKey)
+	require.NoError(t, err)
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := gethCommon.HexToAddress(testAddress)
+	chainId := big.NewInt(1)
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		protocol      Protocol
+		key           *ecdsa.PrivateKey
+		tamper        bool
+		expectedError string
+	}{
+		{
+			name:     "Web3Signer",
+			protocol: ProtocolWeb3Signer,
+			key:      key,
+		},
+		{
+			name:     "Clef",
+			protocol: ProtocolClef,
+			key:      key,
+		},
+		{
+			name:          "Signature from another key is rejected",
+			protocol:      ProtocolWeb3Signer,
+			key:           otherKey,
+			expectedError: "remote signer signed with " + crypto.PubkeyToAddress(otherKey.PublicKey).Hex(),
+		},
+		{
+			name:          "Altered transaction is rejected",
+			protocol:      ProtocolClef,
+			key:           key,
+			tamper:        true,
+			expectedError: "remote signer returned a different transaction",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			stub := newStubSigner(t, tc.protocol, tc.key)
+			stub.tamper = tc.tamper
+			s, err := NewRemote(RemoteParams{
+				URL:      stub.URL,
+				Address:  address,
+				Protocol: tc.protocol,
+				Header:   http.Header{"Authorization": {"Bearer token"}},
+			})
+			require.NoError(t, err)
+			defer s.Close()
+
+			for name, tx := range testTransactions(chainId) {
+				signedTx, err := s.SignTransaction(ctx, tx, chainId)
+				if tc.expectedError != "" {
+					require.Error(t, err, name)
+					assert.Contains(t, err.Error(), tc.expectedError, name)
+					continue
+				}
+				require.NoError(t, err, name)
+				sender, err := types.Sender(types.LatestSignerForChainID(chainId), signedTx)
+				require.NoError(t, err, name)
+				assert.Equal(t, address, sender, name)
+			}
+
+			signature, err := s.SignTypedData(ctx, testTypedData())
+			if tc.expectedError != "" && !tc.tamper {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				expected, err := NewPrivateKey(key).SignTypedData(ctx, testTypedData())
+				require.NoError(t, err)
+				assert.Equal(t, expected, signature, "V is normalized to 0 or 1")
+			}
+
+			for _, header := range stub.headers {
+				assert.Equal(t, "Bearer token", header.Get("Authorization"))
+			}
+			if tc.protocol == ProtocolClef {
+				assert.Contains(t, stub.methods, "account_signTransaction")
+				assert.Contains(t, stub.methods, "account_signTypedData")
+			} else {
+				assert.Contains(t, stub.methods, "eth_signTransaction")
+				assert.Contains(t, stub.methods, "eth_signTypedData")
+			}
+		})
+	}
+}
+
+func TestRemoteSignHash(t *testing.T) {
+	stub := newStubSigner(t, ProtocolWeb3Signer, nil)
+	s, err := NewRemote(RemoteParams{URL: stub.URL, Address: gethCommon.HexToAddress(testAddress)})
+	require.NoError(t, err)
+
+	_, err = s.SignHash(context.Background(), crypto.Keccak256([]byte("message")))
+	require.ErrorIs(t, err, errors.ErrUnsupported)
+	assert.Empty(t, stub.methods)
+}
+
+func TestRemoteSignerError(t *testing.T) {
+	stub := newStubSigner(t, Protocol(-1), nil)
+	s, err := NewRemote(RemoteParams{URL: stub.URL, Address: gethCommon.HexToAddress(testAddress)})
+	require.NoError(t, err)
+	s.methods.signTypedData = "eth_unknown"
+
+	_, err = s.SignTypedData(context.Background(), testTypedData())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "remote signer failed to sign typed data: the method eth_unknown does not exist")
+}
+
+func TestNewRemote(t *testing.T) {
+	tests := []struct {
+		name          string
+		params        RemoteParams
+		expectedError string
+	}{
+		{
+			name:          "Missing URL",
+			params:        RemoteParams{Address: gethCommon.HexToAddress(testAddress)},
+			expectedError: "remote signer url is required",
+		},
+		{
+			name:          "Missing address",
+			params:        RemoteParams{URL: "http://localhost:9000"},
+			expectedError: "remote signer address is required",
+		},
+		{
+			name:          "Unknown protocol",
+			params:        RemoteParams{URL: "http://localhost:9000", Address: gethCommon.HexToAddress(testAddress), Protocol: Protocol(42)},
+			expectedError: "unknown remote signer protocol 42",
+		},
+		{
+			name:          "Unsupported URL scheme",
+			params:        RemoteParams{URL: "ftp://localhost:9000", Address: gethCommon.HexToAddress(testAddress)},
+			expectedError: "failed to create remote signer client",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewRemote(tc.params)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectedError)
+		})
+	}
+}
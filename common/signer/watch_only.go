@@ -0,0 +1,46 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+)
+
+var _ common.Signer = (*WatchOnly)(nil)
+
+// ErrWatchOnly is returned by every signing method of a WatchOnly signer.
+var ErrWatchOnly = errors.New("watch-only signer cannot sign")
+
+// WatchOnly knows the address of an account but holds no key. A wallet created with it can
+// read the account and build its transactions, which are then signed elsewhere, such as on
+// an offline machine with the common/offline package.
+type WatchOnly struct {
+	address gethCommon.Address
+}
+
+// NewWatchOnly returns a signer for address that refuses to sign.
+func NewWatchOnly(address gethCommon.Address) *WatchOnly {
+	return &WatchOnly{address: address}
+}
+
+func (w *WatchOnly) Address() gethCommon.Address {
+	return w.address
+}
+
+func (w *WatchOnly) SignHash(context.Context, []byte) ([]byte, error) {
+	return nil, ErrWatchOnly
+}
+
+func (w *WatchOnly) SignTypedData(context.Context, apitypes.TypedData) ([]byte, error) {
+	return nil, ErrWatchOnly
+}
+
+func (w *WatchOnly) SignTransaction(context.Context, *types.Transaction, *big.Int) (*types.Transaction, error) {
+	return nil, ErrWatchOnly
+}
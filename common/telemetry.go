@@ -0,0 +1,29 @@
+package common
+
+import (
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Telemetry selects the OpenTelemetry providers used to instrument API requests and RPC calls.
+// A nil provider falls back to the global one registered with the otel package, which records
+// nothing until the application installs an SDK. Request spans and metrics carry the endpoint
+// template, chain ID, status code, latency and retry count, and never the API key.
+type Telemetry struct {
+	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
+}
+
+// WithTelemetry instruments API requests with the providers in telemetry.
+func WithTelemetry(telemetry Telemetry) HttpOption {
+	return func(cfg *HttpConfig) {
+		cfg.Telemetry = &telemetry
+	}
+}
+
+// WithWalletTelemetry instruments the wallet's RPC calls with the providers in telemetry.
+func WithWalletTelemetry(telemetry Telemetry) WalletOption {
+	return func(cfg *WalletConfig) {
+		cfg.Telemetry = &telemetry
+	}
+}
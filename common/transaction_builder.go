@@ -17,10 +17,50 @@ type TransactionBuilder interface {
 	SetTo(*gethCommon.Address) TransactionBuilder
 	SetGasTipCap(*big.Int) TransactionBuilder
 	SetGasFeeCap(*big.Int) TransactionBuilder
+	// SetAccessList declares the addresses and storage slots the transaction touches (EIP-2930).
+	SetAccessList(types.AccessList) TransactionBuilder
+	// GenerateAccessList makes the builder ask the node for the access list with
+	// eth_createAccessList before estimating gas, unless one is set.
+	GenerateAccessList() TransactionBuilder
+	// Simulate makes the builder execute the transaction with eth_call against the pending
+	// block before returning it. A revert fails the build with a *revert.Error.
+	Simulate() TransactionBuilder
+	// SetAuthorizationList sets the signed EIP-7702 authorizations, which make the build produce
+	// a set-code transaction.
+	SetAuthorizationList([]types.SetCodeAuthorization) TransactionBuilder
+	// Delegate makes the wallet sign an EIP-7702 authorization that delegates its own account to
+	// contract, valid for the transaction being built. The zero address removes the delegation.
+	Delegate(contract gethCommon.Address) TransactionBuilder
 
 	BuildLegacyTx(context.Context) (*types.Transaction, error)
+	// BuildAccessListTx builds an EIP-2930 transaction: a legacy-priced transaction with an access list.
+	BuildAccessListTx(context.Context) (*types.Transaction, error)
 	BuildDynamicTx(context.Context) (*types.Transaction, error)
+	// BuildSetCodeTx builds an EIP-7702 set-code transaction carrying the authorization list.
+	BuildSetCodeTx(context.Context) (*types.Transaction, error)
+	// Build builds a set-code transaction when authorizations are set, a dynamic-fee transaction
+	// on chains with EIP-1559, and otherwise a legacy transaction, or an access-list transaction
+	// when an access list is set or generated.
 	Build(context.Context) (*types.Transaction, error)
+	// EstimateCost returns the expected cost of tx, usually the transaction just built. On
+	// OP-stack chains and Arbitrum it includes the fee for posting the transaction data to L1.
+	EstimateCost(ctx context.Context, tx *types.Transaction) (*TransactionCost, error)
+}
+
+// TransactionCost is the expected cost of a transaction, in wei of the chain's native token.
+type TransactionCost struct {
+	// L2Gas is the transaction's gas limit, less the gas Arbitrum charges for the L1 data
+	// fee. Using the limit makes the cost an upper bound for the execution part.
+	L2Gas uint64
+	// GasPrice is the expected price per unit of gas
+	GasPrice *big.Int
+	// L2Fee is the execution fee, L2Gas times GasPrice
+	L2Fee *big.Int
+	// L1Fee is the fee for posting the transaction data to L1 on OP-stack chains and
+	// Arbitrum, and zero on other chains
+	L1Fee *big.Int
+	// Total is L2Fee plus L1Fee
+	Total *big.Int
 }
 
 type TransactionBuilderFactory interface {
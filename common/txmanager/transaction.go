@@ -0,0 +1,313 @@
+package txmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Transaction is a transaction tracked by a Manager. Its methods are safe for concurrent use.
+type Transaction struct {
+	manager *Manager
+	actions chan action
+	done    chan struct{}
+	result  Result
+
+	// The fields below are owned by the tracking goroutine
+	candidates   []candidate
+	sentAt       time.Time
+	replacements int
+	included     *candidate
+	receipt      *types.Receipt
+}
+
+// candidate is a broadcast transaction that may be mined: the original or a replacement
+type candidate struct {
+	tx     *types.Transaction
+	cancel bool
+}
+
+type action struct {
+	cancel bool
+	reply  chan error
+}
+
+// Done is closed when the transaction is final or tracking has stopped.
+func (t *Transaction) Done() <-chan struct{} {
+	return t.done
+}
+
+// Result waits until Done is closed and returns the final state.
+func (t *Transaction) Result() Result {
+	<-t.done
+	return t.result
+}
+
+// Wait blocks until the transaction is final and returns its result. It returns ctx.Err()
+// if ctx is done first; tracking continues in that case.
+func (t *Transaction) Wait(ctx context.Context) (Result, error) {
+	select {
+	case <-t.done:
+		return t.result, nil
+	case <-ctx.Done():
+		return Result{}, ctx.Err()
+	}
+}
+
+// SpeedUp replaces the pending transaction with a copy paying higher fees.
+func (t *Transaction) SpeedUp(ctx context.Context) error {
+	return t.request(ctx, false)
+}
+
+// Cancel replaces the pending transaction with a zero-value transfer to the sender, paying
+// higher fees so that miners prefer it. The original may still be mined first.
+func (t *Transaction) Cancel(ctx context.Context) error {
+	return t.request(ctx, true)
+}
+
+func (t *Transaction) request(ctx context.Context, cancel bool) error {
+	a := action{cancel: cancel, reply: make(chan error, 1)}
+	select {
+	case t.actions <- a:
+	case <-t.done:
+		return ErrFinal
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return <-a.reply
+}
+
+func (t *Transaction) run(ctx context.Context) {
+	ticker := time.NewTicker(t.manager.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			t.finish(Result{Status: StatusUnconfirmed, Tx: t.latest().tx, Err: ctx.Err()})
+			return
+		case a := <-t.actions:
+			a.reply <- t.replace(ctx, a.cancel)
+		case <-ticker.C:
+			if result, final := t.poll(ctx); final {
+				t.finish(result)
+				return
+			}
+		}
+	}
+}
+
+func (t *Transaction) finish(result Result) {
+	t.result = result
+	close(t.done)
+	t.manager.emit(Event{Type: EventFinal, Tx: result.Tx, Receipt: result.Receipt, Err: result.Err, Result: &result})
+}
+
+func (t *Transaction) latest() candidate {
+	return t.candidates[len(t.candidates)-1]
+}
+
+// poll looks for a receipt of any candidate, checks that an earlier receipt is still
+// canonical, and counts confirmations. Lookup errors are treated as transient.
+func (t *Transaction) poll(ctx context.Context) (Result, bool) {
+	m := t.manager
+	if t.included == nil {
+		if !t.findReceipt(ctx) {
+			if m.cfg.StuckAfter > 0 && m.cfg.StuckAction != StuckWait && time.Since(t.sentAt) >= m.cfg.StuckAfter &&
+				t.replacements < m.cfg.MaxReplacements {
+				_ = t.replace(ctx, m.cfg.StuckAction == StuckCancel)
+			}
+			return Result{}, false
+		}
+	} else {
+		// The node only returns receipts of canonical blocks, so a missing receipt or one
+		// with another block hash means the block was reorged out
+		receipt, err := m.wallet.TransactionReceipt(ctx, t.included.tx.Hash())
+		switch {
+		case err != nil && !errors.Is(err, ethereum.NotFound):
+			return Result{}, false
+		case receipt != nil && receipt.BlockHash == t.receipt.BlockHash:
+			t.receipt = receipt
+		default:
+			m.emit(Event{Type: EventReorged, Tx: t.included.tx, Receipt: t.receipt})
+			t.included, t.receipt = nil, nil
+			if !t.findReceipt(ctx) {
+				return Result{}, false
+			}
+		}
+	}
+
+	head, err := m.wallet.BlockNumber(ctx)
+	if err != nil {
+		return Result{}, false
+	}
+	block := t.receipt.BlockNumber.Uint64()
+	if head < block || head-block+1 < m.cfg.Confirmations {
+		return Result{}, false
+	}
+
+	status := StatusConfirmed
+	switch {
+	case t.included.cancel:
+		status = StatusCancelled
+	case t.receipt.Status == types.ReceiptStatusFailed:
+		status = StatusReverted
+	}
+	return Result{Status: status, Tx: t.included.tx, Receipt: t.receipt}, true
+}
+
+// findReceipt looks up the receipts of the candidates, newest first, and records the first found
+func (t *Transaction) findReceipt(ctx context.Context) bool {
+	for i := len(t.candidates) - 1; i >= 0; i-- {
+		c := t.candidates[i]
+		receipt, err := t.manager.wallet.TransactionReceipt(ctx, c.tx.Hash())
+		if err != nil || receipt == nil {
+			continue
+		}
+		t.included, t.receipt = &c, receipt
+		t.manager.emit(Event{Type: EventIncluded, Tx: c.tx, Receipt: receipt})
+		return true
+	}
+	return false
+}
+
+// replace signs and broadcasts a fee-bumped copy of the latest candidate, or a cancellation
+func (t *Transaction) replace(ctx context.Context, cancel bool) (err error) {
+	m := t.manager
+	latest := t.latest()
+	defer func() {
+		if err != nil && !errors.Is(err, ErrFinal) {
+			m.emit(Event{Type: EventReplacementFailed, Tx: latest.tx, Err: err})
+		}
+	}()
+
+	if t.included != nil {
+		return ErrFinal
+	}
+	if t.replacements >= m.cfg.MaxReplacements {
+		return fmt.Errorf("transaction was already replaced %d times", t.replacements)
+	}
+	t.replacements++
+	t.sentAt = time.Now()
+
+	// Once cancelled, a transaction stays cancelled: speeding it up bumps the cancellation
+	cancel = cancel || latest.cancel
+	replacement, err := m.replacement(ctx, latest.tx, cancel)
+	if err != nil {
+		return err
+	}
+	signed, err := m.wallet.Sign(replacement)
+	if err != nil {
+		return fmt.Errorf("failed to sign replacement: %w", err)
+	}
+	if err := m.wallet.BroadcastReplacement(ctx, signed); err != nil {
+		// The node refuses the nonce once a candidate is mined, which the next poll picks up
+		if strings.Contains(strings.ToLower(err.Error()), "nonce too low") {
+			return fmt.Errorf("%w: %w", ErrFinal, err)
+		}
+		return err
+	}
+	t.candidates = append(t.candidates, candidate{tx: signed, cancel: cancel})
+	m.emit(Event{Type: EventBroadcast, Tx: signed, Replaces: latest.tx.Hash()})
+	return nil
+}
+
+// replacement builds an unsigned copy of tx with the same nonce and fees raised by the price
+// bump, or to the network's current suggestion when that is higher. A cancellation sends
+// nothing to the sender instead of executing tx, with the gas the node estimates for it,
+// since the cost of a plain transfer is not 21000 on every chain.
+func (m *Manager) replacement(ctx context.Context, tx *types.Transaction, cancel bool) (*types.Transaction, error) {
+	to, value, data, gas, accessList := tx.To(), tx.Value(), tx.Data(), tx.Gas(), tx.AccessList()
+	if cancel {
+		self := m.wallet.Address()
+		estimate, err := m.wallet.GetGasEstimate(ctx, ethereum.CallMsg{From: self, To: &self, Value: new(big.Int)})
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate gas of the cancellation: %w", err)
+		}
+		to, value, data, gas, accessList = &self, new(big.Int), nil, estimate, nil
+	}
+
+	switch tx.Type() {
+	case types.DynamicFeeTxType:
+		tipCap := bump(tx.GasTipCap(), m.cfg.PriceBump)
+		if suggested, err := m.wallet.GetGasTipCap(ctx); err == nil {
+			tipCap = maxBig(tipCap, suggested)
+		}
+		feeCap := bump(tx.GasFeeCap(), m.cfg.PriceBump)
+		if gasPrice, err := m.wallet.GetGasPrice(ctx); err == nil {
+			feeCap = maxBig(feeCap, new(big.Int).Mul(gasPrice, big.NewInt(2)))
+		}
+		feeCap = maxBig(feeCap, tipCap)
+		if err := m.checkCeiling(feeCap); err != nil {
+			return nil, err
+		}
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      tx.Nonce(),
+			GasTipCap:  tipCap,
+			GasFeeCap:  feeCap,
+			Gas:        gas,
+			To:         to,
+			Value:      value,
+			Data:       data,
+			AccessList: accessList,
+		}), nil
+	case types.LegacyTxType, types.AccessListTxType:
+		gasPrice := bump(tx.GasPrice(), m.cfg.PriceBump)
+		if suggested, err := m.wallet.GetGasPrice(ctx); err == nil {
+			gasPrice = maxBig(gasPrice, suggested)
+		}
+		if err := m.checkCeiling(gasPrice); err != nil {
+			return nil, err
+		}
+		if tx.Type() == types.AccessListTxType {
+			return types.NewTx(&types.AccessListTx{
+				ChainID:    tx.ChainId(),
+				Nonce:      tx.Nonce(),
+				GasPrice:   gasPrice,
+				Gas:        gas,
+				To:         to,
+				Value:      value,
+				Data:       data,
+				AccessList: accessList,
+			}), nil
+		}
+		return types.NewTx(&types.LegacyTx{
+			Nonce:    tx.Nonce(),
+			GasPrice: gasPrice,
+			Gas:      gas,
+			To:       to,
+			Value:    value,
+			Data:     data,
+		}), nil
+	default:
+		return nil, fmt.Errorf("cannot replace transactions of type %d", tx.Type())
+	}
+}
+
+func (m *Manager) checkCeiling(fee *big.Int) error {
+	if m.cfg.MaxFeePerGas != nil && fee.Cmp(m.cfg.MaxFeePerGas) > 0 {
+		return fmt.Errorf("%w: %s > %s", ErrFeeCeiling, fee, m.cfg.MaxFeePerGas)
+	}
+	return nil
+}
+
+// bump returns v raised by percent, rounded up so that small values still increase
+func bump(v *big.Int, percent int) *big.Int {
+	bumped := new(big.Int).Mul(v, big.NewInt(int64(100+percent)))
+	bumped.Add(bumped, big.NewInt(99))
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+func maxBig(a *big.Int, b *big.Int) *big.Int {
+	if a.Cmp(b) >= 0 {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,187 @@
+// Package txmanager broadcasts signed transactions and follows them until they are final:
+// it waits for a number of confirmations, notices when a reorg removes or moves the
+// transaction, and replaces transactions that are stuck in the mempool with a fee-bumped
+// copy or a cancellation.
+//
+//	manager := txmanager.NewManager(config.WalletConfiguration.Wallet, txmanager.Config{
+//		Confirmations: 3,
+//		StuckAfter:    time.Minute,
+//		StuckAction:   txmanager.StuckSpeedUp,
+//	})
+//	tracked, err := manager.Send(ctx, signedTx)
+//	...
+//	result, err := tracked.Wait(ctx)
+package txmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+)
+
+var (
+	// ErrFeeCeiling is returned when a replacement would pay more than Config.MaxFeePerGas.
+	ErrFeeCeiling = errors.New("replacement fee exceeds the configured maximum")
+	// ErrFinal is returned by SpeedUp and Cancel once the transaction is mined or tracking has stopped.
+	ErrFinal = errors.New("transaction is no longer pending")
+)
+
+// StuckAction is what the manager does with a transaction that is not mined in time.
+type StuckAction int
+
+const (
+	// StuckWait keeps waiting.
+	StuckWait StuckAction = iota
+	// StuckSpeedUp replaces the transaction with a copy paying higher fees.
+	StuckSpeedUp
+	// StuckCancel replaces the transaction with a zero-value transfer to the sender itself.
+	StuckCancel
+)
+
+// Config tunes a Manager. The zero value waits for one confirmation and never replaces
+// transactions automatically.
+type Config struct {
+	// Confirmations is the number of blocks, including the one holding the transaction, after
+	// which it is final. Zero means 1.
+	Confirmations uint64
+	// PollInterval is the time between receipt lookups. Zero means 2 seconds.
+	PollInterval time.Duration
+	// StuckAfter is how long a transaction may stay unmined before StuckAction is taken,
+	// counted from the latest broadcast. Zero disables automatic replacement.
+	StuckAfter  time.Duration
+	StuckAction StuckAction
+	// PriceBump is the fee increase of each replacement, in percent. Nodes reject replacements
+	// that raise the fees by less than 10% as underpriced, so lower values are raised to 10.
+	PriceBump int
+	// MaxReplacements limits the replacements of one transaction, automatic or not. Zero means 3.
+	MaxReplacements int
+	// MaxFeePerGas is the highest fee cap, or gas price on legacy chains, a replacement may
+	// pay. Nil means no limit.
+	MaxFeePerGas *big.Int
+	// OnEvent, when set, is called from the tracking goroutine for every event.
+	OnEvent func(Event)
+}
+
+// EventType identifies a step in the life of a tracked transaction.
+type EventType int
+
+const (
+	// EventBroadcast is sent for the original transaction and for each replacement.
+	EventBroadcast EventType = iota
+	// EventReplacementFailed is sent when a replacement could not be built or broadcast.
+	EventReplacementFailed
+	// EventIncluded is sent when a receipt is found.
+	EventIncluded
+	// EventReorged is sent when a reorg removes the transaction from its block.
+	EventReorged
+	// EventFinal is sent once, with the Result.
+	EventFinal
+)
+
+// Event describes a change in a tracked transaction.
+type Event struct {
+	Type EventType
+	// Tx is the transaction the event is about
+	Tx *types.Transaction
+	// Replaces is the hash of the transaction Tx replaces, for replacement broadcasts
+	Replaces gethCommon.Hash
+	// Receipt is set for EventIncluded and EventReorged; for EventReorged it is the stale receipt
+	Receipt *types.Receipt
+	Err     error
+	// Result is set for EventFinal
+	Result *Result
+}
+
+// Status is the outcome of a tracked transaction.
+type Status int
+
+const (
+	// StatusUnconfirmed means tracking stopped before the transaction was final; Result.Err says why.
+	StatusUnconfirmed Status = iota
+	// StatusConfirmed means the transaction, or one of its speed-ups, succeeded.
+	StatusConfirmed
+	// StatusReverted means the transaction was mined but reverted.
+	StatusReverted
+	// StatusCancelled means the cancellation was mined instead of the transaction.
+	StatusCancelled
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusUnconfirmed:
+		return "unconfirmed"
+	case StatusConfirmed:
+		return "confirmed"
+	case StatusReverted:
+		return "reverted"
+	case StatusCancelled:
+		return "cancelled"
+	default:
+		return fmt.Sprintf("Status(%d)", int(s))
+	}
+}
+
+// Result is the final state of a tracked transaction.
+type Result struct {
+	Status Status
+	// Tx is the transaction that was mined, which may be a replacement of the one sent, or
+	// the latest broadcast when Status is StatusUnconfirmed
+	Tx      *types.Transaction
+	Receipt *types.Receipt
+	// Err is set when Status is StatusUnconfirmed
+	Err error
+}
+
+// Manager sends and tracks the transactions of one wallet.
+type Manager struct {
+	wallet common.Wallet
+	cfg    Config
+}
+
+// NewManager returns a manager that sends with wallet and follows transactions on its node.
+func NewManager(wallet common.Wallet, cfg Config) *Manager {
+	if cfg.Confirmations == 0 {
+		cfg.Confirmations = 1
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 2 * time.Second
+	}
+	if cfg.PriceBump < 10 {
+		cfg.PriceBump = 10
+	}
+	if cfg.MaxReplacements <= 0 {
+		cfg.MaxReplacements = 3
+	}
+	return &Manager{wallet: wallet, cfg: cfg}
+}
+
+// Send broadcasts the signed transaction tx and tracks it in the background until it is
+// final or ctx is done. An error is returned only when the first broadcast fails.
+func (m *Manager) Send(ctx context.Context, tx *types.Transaction) (*Transaction, error) {
+	if err := m.wallet.BroadcastTransaction(ctx, tx); err != nil {
+		return nil, err
+	}
+	t := &Transaction{
+		manager:    m,
+		candidates: []candidate{{tx: tx}},
+		sentAt:     time.Now(),
+		actions:    make(chan action),
+		done:       make(chan struct{}),
+	}
+	m.emit(Event{Type: EventBroadcast, Tx: tx})
+	go t.run(ctx)
+	return t, nil
+}
+
+func (m *Manager) emit(e Event) {
+	if m.cfg.OnEvent != nil {
+		m.cfg.OnEvent(e)
+	}
+}
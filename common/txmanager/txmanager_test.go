@@ -0,0 +1,379 @@
+package txmanager
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+	"github.com/1inch/1inch-sdk-go/v4/common/signer"
+)
+
+const testPrivateKey = "965e092fdfc08940d2bd05c7b5c7e1c51e283e92c7f52bbf1408973ae9a9acb7"
+
+var chainId = big.NewInt(1)
+
+// fakeWallet is a chain the tests drive by hand: transactions are mined by setting receipts
+type fakeWallet struct {
+	common.Wallet
+	signer *signer.PrivateKey
+
+	mu        sync.Mutex
+	broadcast []*types.Transaction
+	receipts  map[gethCommon.Hash]*types.Receipt
+	head      uint64
+	// replaceErr fails the broadcast of replacements
+	replaceErr error
+}
+
+func newFakeWallet(t *testing.T) *fakeWallet {
+	s, err := signer.NewPrivateKeyFromHex(testPrivateKey)
+	require.NoError(t, err)
+	return &fakeWallet{signer: s, receipts: make(map[gethCommon.Hash]*types.Receipt)}
+}
+
+func (w *fakeWallet) Address() gethCommon.Address {
+	return w.signer.Address()
+}
+
+func (w *fakeWallet) Sign(tx *types.Transaction) (*types.Transaction, error) {
+	return w.signer.SignTransaction(context.Background(), tx, chainId)
+}
+
+func (w *fakeWallet) GetGasTipCap(context.Context) (*big.Int, error) {
+	return big.NewInt(1_000_000_000), nil
+}
+
+func (w *fakeWallet) GetGasPrice(context.Context) (*big.Int, error) {
+	return big.NewInt(10_000_000_000), nil
+}
+
+func (w *fakeWallet) BroadcastTransaction(_ context.Context, tx *types.Transaction) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.broadcast = append(w.broadcast, tx)
+	return nil
+}
+
+func (w *fakeWallet) BroadcastReplacement(ctx context.Context, tx *types.Transaction) error {
+	w.mu.Lock()
+	err := w.replaceErr
+	w.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return w.BroadcastTransaction(ctx, tx)
+}
+
+// GetGasEstimate estimates every call at 25000 gas, above the 21000 of a plain transfer as on
+// chains that charge more intrinsic gas
+func (w *fakeWallet) GetGasEstimate(context.Context, ethereum.CallMsg) (uint64, error) {
+	return 25000, nil
+}
+
+func (w *fakeWallet) TransactionReceipt(_ context.Context, txHash gethCommon.Hash) (*types.Receipt, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	receipt, ok := w.receipts[txHash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+func (w *fakeWallet) BlockNumber(context.Context) (uint64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.head, nil
+}
+
+// mine includes tx in block number with the given block hash and moves the head there
+func (w *fakeWallet) mine(tx *types.Transaction, number uint64, blockHash gethCommon.Hash, status uint64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.receipts[tx.Hash()] = &types.Receipt{Status: status, TxHash: tx.Hash(), BlockHash: blockHash, BlockNumber: new(big.Int).SetUint64(number)}
+	w.head = number
+}
+
+func (w *fakeWallet) unmine(tx *types.Transaction) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.receipts, tx.Hash())
+}
+
+func (w *fakeWallet) setHead(number uint64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.head = number
+}
+
+func (w *fakeWallet) sent() []*types.Transaction {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]*types.Transaction(nil), w.broadcast...)
+}
+
+// eventLog collects the event types a manager reports
+type eventLog struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (l *eventLog) record(e Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, e)
+}
+
+func (l *eventLog) types() []EventType {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var types []EventType
+	for _, e := range l.events {
+		types = append(types, e.Type)
+	}
+	return types
+}
+
+func signedTestTx(t *testing.T, w *fakeWallet) *types.Transaction {
+	to := gethCommon.HexToAddress("0x111111125421ca6dc452d289314280a0f8842a65")
+	tx, err := w.Sign(types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainId,
+		Nonce:     7,
+		GasTipCap: big.NewInt(2_000_000_000),
+		GasFeeCap: big.NewInt(30_000_000_000),
+		Gas:       180000,
+		To:        &to,
+		Value:     big.NewInt(1),
+		Data:      []byte{0x07, 0xed},
+	}))
+	require.NoError(t, err)
+	return tx
+}
+
+func TestTransaction(t *testing.T) {
+	blockA := gethCommon.HexToHash("0xa")
+	blockB := gethCommon.HexToHash("0xb")
+
+	tests := []struct {
+		name           string
+		config         Config
+		drive          func(t *testing.T, w *fakeWallet, tracked *Transaction)
+		expectedStatus Status
+		expectedEvents []EventType
+		// expectedMined is the index in the broadcast transactions of the mined one
+		expectedMined int
+	}{
+		{
+			name:   "Waits for confirmations",
+			config: Config{Confirmations: 3},
+			drive: func(t *testing.T, w *fakeWallet, tracked *Transaction) {
+				w.mine(w.sent()[0], 10, blockA, types.ReceiptStatusSuccessful)
+				w.setHead(11)
+				time.Sleep(20 * time.Millisecond)
+				select {
+				case <-tracked.Done():
+					t.Fatal("final before 3 confirmations")
+				default:
+				}
+				w.setHead(12)
+			},
+			expectedStatus: StatusConfirmed,
+			expectedEvents: []EventType{EventBroadcast, EventIncluded, EventFinal},
+		},
+		{
+			name:   "Reverted transaction",
+			config: Config{},
+			drive: func(t *testing.T, w *fakeWallet, tracked *Transaction) {
+				w.mine(w.sent()[0], 10, blockA, types.ReceiptStatusFailed)
+			},
+			expectedStatus: StatusReverted,
+			expectedEvents: []EventType{EventBroadcast, EventIncluded, EventFinal},
+		},
+		{
+			name:   "Survives a reorg into another block",
+			config: Config{Confirmations: 2},
+			drive: func(t *testing.T, w *fakeWallet, tracked *Transaction) {
+				w.mine(w.sent()[0], 10, blockA, types.ReceiptStatusSuccessful)
+				time.Sleep(20 * time.Millisecond)
+				w.mine(w.sent()[0], 10, blockB, types.ReceiptStatusSuccessful)
+				time.Sleep(20 * time.Millisecond)
+				w.setHead(11)
+			},
+			expectedStatus: StatusConfirmed,
+			expectedEvents: []EventType{EventBroadcast, EventIncluded, EventReorged, EventIncluded, EventFinal},
+		},
+		{
+			name:   "Survives a reorg back to the mempool",
+			config: Config{Confirmations: 2},
+			drive: func(t *testing.T, w *fakeWallet, tracked *Transaction) {
+				w.mine(w.sent()[0], 10, blockA, types.ReceiptStatusSuccessful)
+				time.Sleep(20 * time.Millisecond)
+				w.unmine(w.sent()[0])
+				time.Sleep(20 * time.Millisecond)
+				w.mine(w.sent()[0], 11, blockB, types.ReceiptStatusSuccessful)
+				w.setHead(12)
+			},
+			expectedStatus: StatusConfirmed,
+			expectedEvents: []EventType{EventBroadcast, EventIncluded, EventReorged, EventIncluded, EventFinal},
+		},
+		{
+			name:   "Stuck transaction is sped up",
+			config: Config{StuckAfter: 10 * time.Millisecond, StuckAction: StuckSpeedUp},
+			drive: func(t *testing.T, w *fakeWallet, tracked *Transaction) {
+				require.Eventually(t, func() bool { return len(w.sent()) == 2 }, time.Second, time.Millisecond)
+				w.mine(w.sent()[1], 10, blockA, types.ReceiptStatusSuccessful)
+			},
+			expectedStatus: StatusConfirmed,
+			expectedEvents: []EventType{EventBroadcast, EventBroadcast, EventIncluded, EventFinal},
+			expectedMined:  1,
+		},
+		{
+			name:   "Cancelled transaction",
+			config: Config{},
+			drive: func(t *testing.T, w *fakeWallet, tracked *Transaction) {
+				require.NoError(t, tracked.Cancel(context.Background()))
+				w.mine(w.sent()[1], 10, blockA, types.ReceiptStatusSuccessful)
+			},
+			expectedStatus: StatusCancelled,
+			expectedEvents: []EventType{EventBroadcast, EventBroadcast, EventIncluded, EventFinal},
+			expectedMined:  1,
+		},
+		{
+			name:   "Original mined after a speed-up",
+			config: Config{},
+			drive: func(t *testing.T, w *fakeWallet, tracked *Transaction) {
+				require.NoError(t, tracked.SpeedUp(context.Background()))
+				w.mine(w.sent()[0], 10, blockA, types.ReceiptStatusSuccessful)
+			},
+			expectedStatus: StatusConfirmed,
+			expectedEvents: []EventType{EventBroadcast, EventBroadcast, EventIncluded, EventFinal},
+		},
+		{
+			name:   "Speed-up racing the mined original",
+			config: Config{},
+			drive: func(t *testing.T, w *fakeWallet, tracked *Transaction) {
+				w.mu.Lock()
+				w.replaceErr = errors.New("nonce too low: next nonce 8, tx nonce 7")
+				w.mu.Unlock()
+				require.ErrorIs(t, tracked.SpeedUp(context.Background()), ErrFinal)
+				w.mine(w.sent()[0], 10, blockA, types.ReceiptStatusSuccessful)
+			},
+			expectedStatus: StatusConfirmed,
+			expectedEvents: []EventType{EventBroadcast, EventIncluded, EventFinal},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := newFakeWallet(t)
+			log := &eventLog{}
+			tc.config.PollInterval = time.Millisecond
+			tc.config.OnEvent = log.record
+			manager := NewManager(w, tc.config)
+
+			tracked, err := manager.Send(context.Background(), signedTestTx(t, w))
+			require.NoError(t, err)
+			tc.drive(t, w, tracked)
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			result, err := tracked.Wait(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, result.Status)
+			assert.Equal(t, w.sent()[tc.expectedMined].Hash(), result.Tx.Hash())
+			assert.Equal(t, result.Tx.Hash(), result.Receipt.TxHash)
+			assert.Equal(t, tc.expectedEvents, log.types())
+			assert.ErrorIs(t, tracked.SpeedUp(context.Background()), ErrFinal)
+		})
+	}
+}
+
+func TestReplacement(t *testing.T) {
+	w := newFakeWallet(t)
+	original := signedTestTx(t, w)
+
+	tests := []struct {
+		name             string
+		cancel           bool
+		config           Config
+		expectedTipCap   *big.Int
+		expectedFeeCap   *big.Int
+		expectedTo       gethCommon.Address
+		expectedGas      uint64
+		expectedErrorIs  error
+		expectedDataSize int
+	}{
+		{
+			name:             "Speed-up bumps both fees by 10%",
+			expectedTipCap:   big.NewInt(2_200_000_000),
+			expectedFeeCap:   big.NewInt(33_000_000_000),
+			expectedTo:       *original.To(),
+			expectedGas:      original.Gas(),
+			expectedDataSize: len(original.Data()),
+		},
+		{
+			name:             "Price bump above 10%",
+			config:           Config{PriceBump: 50},
+			expectedTipCap:   big.NewInt(3_000_000_000),
+			expectedFeeCap:   big.NewInt(45_000_000_000),
+			expectedTo:       *original.To(),
+			expectedGas:      original.Gas(),
+			expectedDataSize: len(original.Data()),
+		},
+		{
+			name:           "Cancel is a zero-value self-transfer",
+			cancel:         true,
+			expectedTipCap: big.NewInt(2_200_000_000),
+			expectedFeeCap: big.NewInt(33_000_000_000),
+			expectedTo:     w.Address(),
+			expectedGas:    25000,
+		},
+		{
+			name:            "Fee ceiling",
+			config:          Config{MaxFeePerGas: big.NewInt(32_000_000_000)},
+			expectedErrorIs: ErrFeeCeiling,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			replacement, err := NewManager(w, tc.config).replacement(context.Background(), original, tc.cancel)
+			if tc.expectedErrorIs != nil {
+				require.ErrorIs(t, err, tc.expectedErrorIs)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, original.Nonce(), replacement.Nonce())
+			assert.Equal(t, tc.expectedTipCap, replacement.GasTipCap())
+			assert.Equal(t, tc.expectedFeeCap, replacement.GasFeeCap())
+			assert.Equal(t, tc.expectedTo, *replacement.To())
+			assert.Equal(t, tc.expectedGas, replacement.Gas())
+			assert.Len(t, replacement.Data(), tc.expectedDataSize)
+			if tc.cancel {
+				assert.Zero(t, replacement.Value().Sign())
+			}
+		})
+	}
+}
+
+func TestTransactionContextDone(t *testing.T) {
+	w := newFakeWallet(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	tracked, err := NewManager(w, Config{PollInterval: time.Millisecond}).Send(ctx, signedTestTx(t, w))
+	require.NoError(t, err)
+
+	cancel()
+	result := tracked.Result()
+	assert.Equal(t, StatusUnconfirmed, result.Status)
+	assert.True(t, errors.Is(result.Err, context.Canceled))
+}
@@ -7,10 +7,14 @@ import (
 	"github.com/ethereum/go-ethereum"
 	gethCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 )
 
 type Wallet interface {
 	Call(ctx context.Context, contractAddress gethCommon.Address, callData []byte) ([]byte, error)
+	// Simulate executes msg with eth_call against the pending block. A revert is returned as a
+	// *revert.Error with the decoded custom error, revert reason or panic code.
+	Simulate(ctx context.Context, msg ethereum.CallMsg) ([]byte, error)
 
 	Nonce(ctx context.Context) (uint64, error)
 	Address() gethCommon.Address
@@ -19,25 +23,44 @@ type Wallet interface {
 	GetGasTipCap(ctx context.Context) (*big.Int, error)
 	GetGasPrice(ctx context.Context) (*big.Int, error)
 	GetGasEstimate(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+	CreateAccessList(ctx context.Context, msg ethereum.CallMsg) (types.AccessList, error)
 
 	Sign(tx *types.Transaction) (*types.Transaction, error)
 	SignBytes(data []byte) ([]byte, error)
+	SignTypedData(typedData apitypes.TypedData) ([]byte, error)
+	// SignAuthorization signs an EIP-7702 authorization that delegates the wallet's account to
+	// auth.Address. A zero auth.ChainID makes the authorization valid on every chain.
+	SignAuthorization(ctx context.Context, auth types.SetCodeAuthorization) (types.SetCodeAuthorization, error)
 	BroadcastTransaction(ctx context.Context, tx *types.Transaction) error
+	// BroadcastReplacement broadcasts tx, which replaces a broadcast transaction with the same
+	// nonce, without reporting to the nonce manager: the nonce was already sent.
+	BroadcastReplacement(ctx context.Context, tx *types.Transaction) error
 	TransactionReceipt(ctx context.Context, txHash gethCommon.Hash) (*types.Receipt, error)
+	BlockNumber(ctx context.Context) (uint64, error)
 
 	GetContractDetailsForPermit(ctx context.Context, token gethCommon.Address, spender gethCommon.Address, amount *big.Int, deadline int64) (*ContractPermitData, error)
 	GetContractDetailsForPermitDaiLike(ctx context.Context, token gethCommon.Address, spender gethCommon.Address, deadline int64) (*ContractPermitDataDaiLike, error)
 	TokenPermit(cd ContractPermitData) (string, error)
 	TokenPermitDaiLike(cd ContractPermitDataDaiLike) (string, error)
+	// DetectPermit probes token for ERC-2612 and DAI-like permit support and finds the EIP-712
+	// domain that matches its DOMAIN_SEPARATOR. Tokens without permit are reported as
+	// PermitUnsupported rather than as an error.
+	DetectPermit(ctx context.Context, token gethCommon.Address) (*PermitSupport, error)
 
 	IsEIP1559Applicable() bool
 	ChainId() int64
-	//TokenApprove()
 
-	// view functions
-	//TokenBalance()
-	//TokenAllowance()
+	// ERC-20 view functions. The plural forms read many tokens in a single multicall.
+	TokenBalance(ctx context.Context, token gethCommon.Address) (*big.Int, error)
+	TokenBalances(ctx context.Context, tokens []gethCommon.Address) ([]*big.Int, error)
+	TokenAllowance(ctx context.Context, token gethCommon.Address, spender gethCommon.Address) (*big.Int, error)
+	TokenAllowances(ctx context.Context, tokens []gethCommon.Address, spender gethCommon.Address) ([]*big.Int, error)
+	TokenDecimals(ctx context.Context, token gethCommon.Address) (uint8, error)
+	TokenSymbol(ctx context.Context, token gethCommon.Address) (string, error)
 
+	// ERC-20 transactions, built, signed and broadcast by the wallet
+	TokenApprove(ctx context.Context, token gethCommon.Address, spender gethCommon.Address, amount *big.Int) (*types.Transaction, error)
+	TokenTransfer(ctx context.Context, token gethCommon.Address, to gethCommon.Address, amount *big.Int) (*types.Transaction, error)
 }
 
 type ContractPermitData struct {
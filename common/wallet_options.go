@@ -0,0 +1,36 @@
+package common
+
+import "log/slog"
+
+// WalletOption customizes the wallet that an SDK client's configuration builds.
+// Options are applied in order, so a later option overrides an earlier one.
+type WalletOption func(*WalletConfig)
+
+// WalletConfig holds the wallet settings collected from WalletOption values.
+type WalletConfig struct {
+	// Telemetry instruments RPC calls. Nil uses the global OpenTelemetry providers.
+	Telemetry *Telemetry
+	// Logger receives debug logs of built, signed and broadcast transactions. Nil disables logging.
+	Logger *slog.Logger
+	// Signer signs transactions and messages. Nil signs with the configured private key.
+	Signer Signer
+	// NonceManager hands out transaction nonces. Nil reads the latest nonce from the node for every transaction.
+	NonceManager NonceManager
+	// FeeStrategy chooses transaction fees in the transaction builder. Nil uses the node's suggestions.
+	FeeStrategy FeeStrategy
+	// NodeURLs are node endpoints used next to the wallet's node URL, with failover.
+	NodeURLs []string
+	// NodePolicy configures failover and quorum reads over the node endpoints.
+	NodePolicy NodePolicy
+}
+
+// NewWalletConfig applies opts to an empty WalletConfig.
+func NewWalletConfig(opts ...WalletOption) WalletConfig {
+	var cfg WalletConfig
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	return cfg
+}
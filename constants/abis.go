@@ -10,3 +10,32 @@ var SeriesNonceManagerABI string
 
 //go:embed abi/aggregationRouterV6.abi.json
 var AggregationRouterV6ABI string
+
+//go:embed abi/multicall3.abi.json
+var Multicall3ABI string
+
+// FusionSettlementErrorsABI holds the custom errors of the Fusion settlement extension.
+//
+//go:embed abi/fusionSettlementErrors.abi.json
+var FusionSettlementErrorsABI string
+
+// Permit2ErrorsABI holds the custom errors of Uniswap's Permit2 contract.
+//
+//go:embed abi/permit2Errors.abi.json
+var Permit2ErrorsABI string
+
+// OpGasPriceOracleABI is the getL1Fee method of the OP-stack GasPriceOracle predeploy.
+//
+//go:embed abi/opGasPriceOracle.abi.json
+var OpGasPriceOracleABI string
+
+// ArbitrumNodeInterfaceABI is the gasEstimateComponents method of Arbitrum's NodeInterface.
+//
+//go:embed abi/arbitrumNodeInterface.abi.json
+var ArbitrumNodeInterfaceABI string
+
+// Eip712DomainABI is the DOMAIN_SEPARATOR getter and the EIP-5267 eip712Domain method of
+// contracts that verify EIP-712 signatures.
+//
+//go:embed abi/eip712Domain.abi.json
+var Eip712DomainABI string
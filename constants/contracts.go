@@ -15,6 +15,21 @@ const Erc20ApproveGas = 45_000
 // https://github.com/Uniswap/permit2
 const Permit2Address = "0x000000000022d473030f116ddee9f6b43ac78ba3"
 
+// Multicall3Address is the canonical Multicall3 contract, deployed at the same address on most chains
+// https://github.com/mds1/multicall3
+const Multicall3Address = "0xcA11bde05977b3631167028862bE2a173976CA11"
+const Multicall3ZkSyncEra = "0xF9cda624FBC7e059355ce98a31693d299FACd963"
+
+// OpGasPriceOracleAddress is the GasPriceOracle predeploy of OP-stack chains (Optimism, Base),
+// which prices the L1 data fee of a transaction
+// https://specs.optimism.io/protocol/predeploys.html#gaspriceoracle
+const OpGasPriceOracleAddress = "0x420000000000000000000000000000000000000F"
+
+// ArbitrumNodeInterfaceAddress is Arbitrum's NodeInterface, a virtual contract that only
+// answers eth_call and eth_estimateGas
+// https://docs.arbitrum.io/build-decentralized-apps/nodeinterface/reference
+const ArbitrumNodeInterfaceAddress = "0x00000000000000000000000000000000000000C8"
+
 // Series Nonce Manager contract addresses are taken from limit-order-protocol/deployments
 
 const SeriesNonceManagerArbitrum = "0xD7936052D1e096d48C81Ef3918F9Fd6384108480"
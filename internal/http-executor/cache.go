@@ -0,0 +1,100 @@
+package http_executor
+
+import (
+	"context"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+	"github.com/1inch/1inch-sdk-go/v4/common/httpcache"
+)
+
+// defaultSharedFetchTimeout bounds a shared fetch when the client has no per-attempt timeout
+const defaultSharedFetchTimeout = time.Minute
+
+// responseCache caches successful GET response bodies and collapses concurrent
+// identical requests into one.
+type responseCache struct {
+	store common.CacheStore
+	rules []common.CacheRule
+	// timeout bounds a shared fetch, which no caller's context can cancel
+	timeout time.Duration
+
+	mu       sync.Mutex
+	inflight map[string]*call
+}
+
+type call struct {
+	done chan struct{}
+	body []byte
+	err  error
+}
+
+func newResponseCache(policy *common.CachePolicy, timeout time.Duration) *responseCache {
+	if policy == nil {
+		return nil
+	}
+	store := policy.Store
+	if store == nil {
+		store = httpcache.NewLRU(httpcache.DefaultCapacity)
+	}
+	return &responseCache{
+		store:    store,
+		rules:    policy.Rules,
+		timeout:  timeout,
+		inflight: make(map[string]*call),
+	}
+}
+
+// ttl returns how long responses for urlPath are cached, or 0 if they are not.
+func (c *responseCache) ttl(urlPath string) time.Duration {
+	for _, rule := range c.rules {
+		if ok, _ := path.Match(rule.Pattern, urlPath); ok {
+			return rule.TTL
+		}
+	}
+	return 0
+}
+
+// get returns the cached body for key, or calls fetch once for all concurrent callers
+// asking for the same key and caches its result on success. The fetch runs in the background
+// on a context detached from the caller that started it, so that its cancellation does not
+// fail the others; every caller stops waiting when its own ctx is done.
+func (c *responseCache) get(ctx context.Context, key string, ttl time.Duration, fetch func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	if body, ok := c.store.Get(key); ok {
+		return body, nil
+	}
+
+	c.mu.Lock()
+	current, ok := c.inflight[key]
+	if !ok {
+		current = &call{done: make(chan struct{})}
+		c.inflight[key] = current
+		go c.fetch(context.WithoutCancel(ctx), key, ttl, current, fetch)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-current.done:
+		return current.body, current.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// fetch runs a shared fetch for key, bounded by the cache timeout, and publishes its result
+func (c *responseCache) fetch(ctx context.Context, key string, ttl time.Duration, current *call, fetch func(ctx context.Context) ([]byte, error)) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	current.body, current.err = fetch(ctx)
+	if current.err == nil {
+		c.store.Set(key, current.body, ttl)
+	}
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	c.mu.Unlock()
+	close(current.done)
+}
@@ -0,0 +1,189 @@
+package http_executor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+)
+
+func TestDefaultCacheRules(t *testing.T) {
+	cache := newResponseCache(&common.CachePolicy{Rules: common.DefaultCacheRules()}, defaultSharedFetchTimeout)
+
+	tests := []struct {
+		name     string
+		path     string
+		expected time.Duration
+	}{
+		{name: "Aggregation tokens", path: "/swap/v6.0/1/tokens", expected: time.Hour},
+		{name: "Aggregation liquidity sources", path: "/swap/v6.0/1/liquidity-sources", expected: time.Hour},
+		{name: "Aggregation approve spender", path: "/swap/v6.0/1/approve/spender", expected: time.Hour},
+		{name: "Fusion settlement contract", path: "/fusion/orders/v2.0/1/order/settlement", expected: time.Hour},
+		{name: "Fusion plus escrow factory", path: "/fusion-plus/orders/v1.1/order/escrow", expected: time.Hour},
+		{name: "Whitelisted tokens", path: "/token/v1.2/1", expected: 10 * time.Minute},
+		{name: "Whitelisted tokens list", path: "/token/v1.2/1/token-list", expected: 10 * time.Minute},
+		{name: "Spot price currencies", path: "/price/v1.1/1/currencies", expected: time.Hour},
+		{name: "NFT supported chains", path: "/nft/v1/supportedchains", expected: time.Hour},
+		{name: "Quotes are not cached", path: "/swap/v6.0/1/quote", expected: 0},
+		{name: "Allowances are not cached", path: "/swap/v6.0/1/approve/allowance", expected: 0},
+		{name: "Token search is not cached", path: "/token/v1.2/search", expected: 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, cache.ttl(tc.path))
+		})
+	}
+}
+
+func TestExecuteRequest_Cache(t *testing.T) {
+	tests := []struct {
+		name             string
+		method           string
+		path             string
+		status           int
+		expectedRequests int32
+	}{
+		{
+			name:             "Cached endpoint is requested once",
+			method:           http.MethodGet,
+			path:             "/swap/v6.0/1/tokens",
+			status:           http.StatusOK,
+			expectedRequests: 1,
+		},
+		{
+			name:             "Uncached endpoint is requested every time",
+			method:           http.MethodGet,
+			path:             "/swap/v6.0/1/quote",
+			status:           http.StatusOK,
+			expectedRequests: 3,
+		},
+		{
+			name:             "POST requests are never cached",
+			method:           http.MethodPost,
+			path:             "/swap/v6.0/1/tokens",
+			status:           http.StatusOK,
+			expectedRequests: 3,
+		},
+		{
+			name:             "Errors are not cached",
+			method:           http.MethodGet,
+			path:             "/swap/v6.0/1/tokens",
+			status:           http.StatusBadRequest,
+			expectedRequests: 3,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests atomic.Int32
+			mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := requests.Add(1)
+				w.WriteHeader(tc.status)
+				_, _ = io.WriteString(w, fmt.Sprintf(`{"n":%d}`, n))
+			}))
+			defer mockServer.Close()
+
+			client, err := DefaultHttpClient(mockServer.URL, "testApiKey", common.WithCache(common.CachePolicy{Rules: common.DefaultCacheRules()}))
+			require.NoError(t, err)
+
+			for i := 0; i < 3; i++ {
+				var result map[string]int
+				err := client.ExecuteRequest(context.Background(), common.RequestPayload{Method: tc.method, U: tc.path}, &result)
+				if tc.status != http.StatusOK {
+					require.Error(t, err)
+					continue
+				}
+				require.NoError(t, err)
+				if tc.expectedRequests == 1 {
+					assert.Equal(t, 1, result["n"])
+				}
+			}
+			assert.Equal(t, tc.expectedRequests, requests.Load())
+		})
+	}
+}
+
+func TestExecuteRequest_CacheCollapsesConcurrentRequests(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+		_, _ = io.WriteString(w, `{"result":"success"}`)
+	}))
+	defer mockServer.Close()
+
+	client, err := DefaultHttpClient(mockServer.URL, "testApiKey", common.WithCache(common.CachePolicy{Rules: common.DefaultCacheRules()}))
+	require.NoError(t, err)
+
+	const callers = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var result map[string]string
+			errs <- client.ExecuteRequest(context.Background(), common.RequestPayload{Method: http.MethodGet, U: "/nft/v1/supportedchains"}, &result)
+		}()
+	}
+
+	require.Eventually(t, func() bool { return requests.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestExecuteRequest_CacheLeaderCancellation(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+		_, _ = io.WriteString(w, `{"result":"success"}`)
+	}))
+	defer mockServer.Close()
+
+	client, err := DefaultHttpClient(mockServer.URL, "testApiKey", common.WithCache(common.CachePolicy{Rules: common.DefaultCacheRules()}))
+	require.NoError(t, err)
+	payload := common.RequestPayload{Method: http.MethodGet, U: "/nft/v1/supportedchains"}
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		var result map[string]string
+		leaderErr <- client.ExecuteRequest(leaderCtx, payload, &result)
+	}()
+	require.Eventually(t, func() bool { return requests.Load() == 1 }, time.Second, time.Millisecond)
+
+	waiterErr := make(chan error, 1)
+	var waiterResult map[string]string
+	go func() {
+		waiterErr <- client.ExecuteRequest(context.Background(), payload, &waiterResult)
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	cancel()
+	require.ErrorIs(t, <-leaderErr, context.Canceled)
+	close(release)
+	require.NoError(t, <-waiterErr)
+	assert.Equal(t, "success", waiterResult["result"])
+	assert.Equal(t, int32(1), requests.Load())
+}
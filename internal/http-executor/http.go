@@ -6,28 +6,63 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"math/big"
 	"net/http"
 	"net/url"
 	"reflect"
 	"regexp"
+	"strings"
+	"time"
 
 	"github.com/google/go-querystring/query"
 
 	"github.com/1inch/1inch-sdk-go/v4/common"
+	"github.com/1inch/1inch-sdk-go/v4/internal/logging"
+	"github.com/1inch/1inch-sdk-go/v4/internal/telemetry"
 )
 
 var scientificNotationRegex = regexp.MustCompile(`^[+-]?\d+(\.\d+)?[eE][+-]?\d+$`)
 
-func DefaultHttpClient(apiUrl string, apiKey string) (*Client, error) {
+// NewExecutor returns the executor selected by opts: the custom one passed with
+// common.WithExecutor, or the SDK's default client configured with the remaining options.
+func NewExecutor(apiUrl string, apiKey string, opts ...common.HttpOption) (common.HttpExecutor, error) {
+	if cfg := common.NewHttpConfig(opts...); cfg.Executor != nil {
+		return cfg.Executor, nil
+	}
+	return DefaultHttpClient(apiUrl, apiKey, opts...)
+}
+
+func DefaultHttpClient(apiUrl string, apiKey string, opts ...common.HttpOption) (*Client, error) {
 	baseURL, err := url.Parse(apiUrl)
 	if err != nil {
 		return nil, err
 	}
+	cfg := common.NewHttpConfig(opts...)
+
+	httpClient := *http.DefaultClient
+	if cfg.HttpClient != nil {
+		httpClient = *cfg.HttpClient
+	}
+	if cfg.Transport != nil {
+		httpClient.Transport = cfg.Transport
+	}
+	if cfg.Timeout > 0 {
+		httpClient.Timeout = cfg.Timeout
+	}
+	if len(cfg.Middleware) > 0 {
+		httpClient.Transport = common.ChainMiddleware(httpClient.Transport, cfg.Middleware...)
+	}
+
 	return &Client{
-		httpClient: *http.DefaultClient,
-		baseURL:    baseURL,
-		apiKey:     apiKey,
+		httpClient:  httpClient,
+		baseURL:     baseURL,
+		apiKey:      apiKey,
+		retryPolicy: cfg.RetryPolicy,
+		rateLimiter: cfg.RateLimiter,
+		cache:       newResponseCache(cfg.Cache, sharedFetchTimeout(cfg)),
+		telemetry:   telemetry.New(cfg.Telemetry),
+		logger:      logging.New(cfg.Logger),
 	}, nil
 }
 
@@ -38,6 +73,16 @@ type Client struct {
 	baseURL *url.URL
 	// The API key to use for authentication
 	apiKey string
+	// Controls automatic retries; nil disables them
+	retryPolicy *common.RetryPolicy
+	// Throttles outgoing requests; nil disables client-side rate limiting
+	rateLimiter common.RateLimiter
+	// Caches GET responses for selected endpoints; nil disables caching
+	cache *responseCache
+	// Records spans and metrics for requests; nil records nothing
+	telemetry *telemetry.Instruments
+	// Receives debug logs of requests with secrets redacted; nil disables logging
+	logger *slog.Logger
 }
 
 func (c *Client) ExecuteRequest(ctx context.Context, payload common.RequestPayload, v any) error {
@@ -51,23 +96,155 @@ func (c *Client) ExecuteRequest(ctx context.Context, payload common.RequestPaylo
 		return err
 	}
 
-	req, err := c.prepareRequest(ctx, payload.Method, fullURL, payload.Body)
-	if err != nil {
-		return fmt.Errorf("failed to prepare request: %w", err)
+	var body []byte
+	if ttl := c.cacheTTL(payload.Method, fullURL); ttl > 0 {
+		body, err = c.cache.get(ctx, payload.Method+" "+fullURL.String(), ttl, func(ctx context.Context) ([]byte, error) {
+			return c.fetch(ctx, payload.Method, fullURL, payload.Body)
+		})
+	} else {
+		body, err = c.fetch(ctx, payload.Method, fullURL, payload.Body)
 	}
-
-	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		return err
 	}
 
-	if err = c.processResponse(resp, v); err != nil {
+	if err = decodeResponse(body, v); err != nil {
 		return fmt.Errorf("failed to process response: %w", err)
 	}
 
 	return nil
 }
 
+// sharedFetchTimeout bounds a cached GET shared by concurrent callers: every attempt may use
+// the whole per-attempt timeout. Without one the default applies.
+func sharedFetchTimeout(cfg common.HttpConfig) time.Duration {
+	if cfg.Timeout <= 0 {
+		return defaultSharedFetchTimeout
+	}
+	return cfg.Timeout * time.Duration(maxAttempts(cfg.RetryPolicy, http.MethodGet))
+}
+
+// cacheTTL returns how long the response to a request may be cached, or 0 if it may not.
+func (c *Client) cacheTTL(method string, fullURL *url.URL) time.Duration {
+	if c.cache == nil || method != http.MethodGet {
+		return 0
+	}
+	return c.cache.ttl(fullURL.Path)
+}
+
+// fetch sends the request and returns the body of a successful response.
+func (c *Client) fetch(ctx context.Context, method string, fullURL *url.URL, body []byte) ([]byte, error) {
+	resp, err := c.doWithRetry(ctx, method, fullURL, body)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := c.processResponse(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process response: %w", err)
+	}
+
+	return respBody, nil
+}
+
+// doWithRetry sends the request, retrying network errors, 429 and 5xx responses
+// according to the client's retry policy. The last response is returned as is,
+// so a final failure status is still reported by processResponse.
+func (c *Client) doWithRetry(ctx context.Context, method string, fullURL *url.URL, body []byte) (*http.Response, error) {
+	start := time.Now()
+	ctx, request := c.telemetry.StartAPIRequest(ctx, method, fullURL.Path)
+
+	resp, retries, err := c.sendWithRetry(ctx, method, fullURL, body)
+
+	statusCode := 0
+	if resp != nil {
+		statusCode = resp.StatusCode
+	}
+	request.End(statusCode, retries, err)
+	c.logRequest(ctx, method, fullURL, statusCode, retries, time.Since(start), err)
+
+	return resp, err
+}
+
+// logRequest logs a finished request at debug level. Query parameters are logged one by one
+// so that the logger redacts sensitive ones; headers, which carry the API key, are never logged.
+func (c *Client) logRequest(ctx context.Context, method string, fullURL *url.URL, statusCode int, retries int, duration time.Duration, err error) {
+	if c.logger == nil || !c.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	template, _ := telemetry.EndpointTemplate(fullURL.Path)
+	query := fullURL.Query()
+	queryAttrs := make([]any, 0, len(query))
+	for key, values := range query {
+		queryAttrs = append(queryAttrs, slog.String(key, strings.Join(values, ",")))
+	}
+
+	args := []any{
+		slog.String("method", method),
+		slog.String("endpoint", template),
+		slog.Group("query", queryAttrs...),
+		slog.Int("status", statusCode),
+		slog.Int("retries", retries),
+		slog.Duration("duration", duration),
+	}
+	if err != nil {
+		// transport errors quote the full URL, whose query may hold secrets
+		message := strings.ReplaceAll(err.Error(), fullURL.String(), fullURL.Scheme+"://"+fullURL.Host+template)
+		args = append(args, slog.String("error", message))
+	}
+	c.logger.DebugContext(ctx, "1inch API request", args...)
+}
+
+// sendWithRetry implements doWithRetry and also returns the number of retries made.
+func (c *Client) sendWithRetry(ctx context.Context, method string, fullURL *url.URL, body []byte) (resp *http.Response, retries int, err error) {
+	attempts := maxAttempts(c.retryPolicy, method)
+	for attempt := 1; ; attempt++ {
+		retries = attempt - 1
+		if c.rateLimiter != nil {
+			if err := c.rateLimiter.Wait(ctx, c.apiKey, fullURL.Path); err != nil {
+				return nil, retries, fmt.Errorf("failed to wait for rate limiter: %w", err)
+			}
+		}
+
+		req, err := c.prepareRequest(ctx, method, fullURL, body)
+		if err != nil {
+			return nil, retries, fmt.Errorf("failed to prepare request: %w", err)
+		}
+
+		resp, err = c.httpClient.Do(req)
+		if err != nil {
+			if attempt >= attempts || !isRetryableError(ctx, err) {
+				return nil, retries, fmt.Errorf("failed to execute request: %w", err)
+			}
+			if err := sleep(ctx, backoff(c.retryPolicy, attempt)); err != nil {
+				return nil, retries, fmt.Errorf("failed to execute request: %w", err)
+			}
+			continue
+		}
+
+		if attempt >= attempts || !isRetryableStatus(resp.StatusCode) {
+			return resp, retries, nil
+		}
+
+		wait := backoff(c.retryPolicy, attempt)
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			// A server asking for a longer wait would hold the caller indefinitely, so its
+			// answer is returned as the final one
+			if retryAfter > maxRetryAfter(c.retryPolicy) {
+				return resp, retries, nil
+			}
+			wait = retryAfter
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		if err := sleep(ctx, wait); err != nil {
+			return nil, retries, fmt.Errorf("failed to execute request: %w", err)
+		}
+	}
+}
+
 func (c *Client) prepareRequest(ctx context.Context, method string, fullURL *url.URL, body []byte) (*http.Request, error) {
 	req, err := http.NewRequestWithContext(ctx, method, fullURL.String(), bytes.NewBuffer(body))
 	if err != nil {
@@ -83,25 +260,23 @@ func (c *Client) prepareRequest(ctx context.Context, method string, fullURL *url
 	return req, nil
 }
 
-func (c *Client) processResponse(resp *http.Response, v any) error {
+func (c *Client) processResponse(resp *http.Response) ([]byte, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
-		return c.handleErrorResponse(resp)
+		return nil, c.handleErrorResponse(resp)
 	}
 
-	buf := new(bytes.Buffer)
-	_, err := buf.ReadFrom(resp.Body)
-	if err != nil {
-		return err
-	}
+	return io.ReadAll(resp.Body)
+}
 
-	if buf.Len() == 0 {
+func decodeResponse(body []byte, v any) error {
+	if len(body) == 0 {
 		return nil // No content to decode
 	}
 
 	if v != nil {
-		return json.NewDecoder(buf).Decode(v)
+		return json.NewDecoder(bytes.NewReader(body)).Decode(v)
 	}
 
 	return nil
@@ -113,18 +288,12 @@ func (c *Client) handleErrorResponse(resp *http.Response) error {
 		return fmt.Errorf("failed to read error response body: %w", err)
 	}
 
-	var errorMessageMap map[string]any
-	err = json.Unmarshal(body, &errorMessageMap)
-	if err != nil {
-		return fmt.Errorf("failed to unmarshal error response body: %w", err)
-	}
-
-	errFormatted, err := json.MarshalIndent(errorMessageMap, "", "    ")
-	if err != nil {
-		return fmt.Errorf("failed to format error response body: %w", err)
+	endpoint := ""
+	if resp.Request != nil {
+		endpoint = resp.Request.Method + " " + resp.Request.URL.Path
 	}
 
-	return fmt.Errorf("%s", errFormatted)
+	return common.NewAPIError(resp.StatusCode, endpoint, body)
 }
 
 // addQueryParameters adds the parameters in the struct params as URL query parameters to s.
@@ -1,17 +1,21 @@
 package http_executor
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
-	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 
 	"github.com/1inch/1inch-sdk-go/v4/common"
 )
@@ -127,13 +131,71 @@ func TestExecuteRequest_ServerErrorPOST(t *testing.T) {
 		t.Fatalf("Expected an error, got nil")
 	}
 
-	expectedErrorMessage := `failed to process response: {
-	"message": "internal server error"
-}`
+	expectedErrorMessage := "failed to process response: 1inch API error: status 500 from POST /error: internal server error"
+	assert.Equal(t, expectedErrorMessage, err.Error())
 
-	// Remove all whitespace when comparing the errors
-	if strings.Join(strings.Fields(err.Error()), "") != strings.Join(strings.Fields(expectedErrorMessage), "") {
-		t.Errorf("Expected error message '%s', got '%s'", expectedErrorMessage, err.Error())
+	var apiErr *common.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+	assert.Equal(t, "POST /error", apiErr.Endpoint)
+}
+
+func TestExecuteRequest_APIError(t *testing.T) {
+	tests := []struct {
+		name                string
+		status              int
+		body                string
+		expectedRequestID   string
+		expectedErrorCode   string
+		expectedDescription string
+		expectedMeta        []common.APIErrorMeta
+		expectedSentinel    error
+	}{
+		{
+			name:                "1inch error body",
+			status:              http.StatusBadRequest,
+			body:                `{"error":"Bad Request","description":"insufficient liquidity","statusCode":400,"requestId":"abc-123","meta":[{"type":"src","value":"0x1"}]}`,
+			expectedRequestID:   "abc-123",
+			expectedErrorCode:   "Bad Request",
+			expectedDescription: "insufficient liquidity",
+			expectedMeta:        []common.APIErrorMeta{{Type: "src", Value: "0x1"}},
+			expectedSentinel:    common.ErrInsufficientLiquidity,
+		},
+		{
+			name:             "Gateway HTML body keeps the status code",
+			status:           http.StatusTooManyRequests,
+			body:             `<html><body>Too Many Requests</body></html>`,
+			expectedSentinel: common.ErrRateLimited,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				_, _ = io.WriteString(w, tc.body)
+			}))
+			defer mockServer.Close()
+
+			client := Client{
+				httpClient: *mockServer.Client(),
+				baseURL:    mustParseURL(mockServer.URL),
+				apiKey:     "testApiKey",
+			}
+
+			err := client.ExecuteRequest(context.Background(), common.RequestPayload{Method: "GET", U: "/swap/v6.1/1/quote"}, nil)
+
+			var apiErr *common.APIError
+			require.ErrorAs(t, err, &apiErr)
+			assert.Equal(t, tc.status, apiErr.StatusCode)
+			assert.Equal(t, "GET /swap/v6.1/1/quote", apiErr.Endpoint)
+			assert.Equal(t, tc.expectedRequestID, apiErr.RequestID)
+			assert.Equal(t, tc.expectedErrorCode, apiErr.ErrorCode)
+			assert.Equal(t, tc.expectedDescription, apiErr.Description)
+			assert.Equal(t, tc.expectedMeta, apiErr.Meta)
+			assert.Equal(t, tc.body, string(apiErr.RawBody))
+			assert.ErrorIs(t, err, tc.expectedSentinel)
+		})
 	}
 }
 
@@ -273,3 +335,299 @@ func TestExpandScientificNotation(t *testing.T) {
 		})
 	}
 }
+
+type recordingRateLimiter struct {
+	calls []string
+	err   error
+}
+
+func (r *recordingRateLimiter) Wait(_ context.Context, apiKey string, path string) error {
+	r.calls = append(r.calls, apiKey+" "+path)
+	return r.err
+}
+
+func TestExecuteRequest_RateLimiter(t *testing.T) {
+	tests := []struct {
+		name             string
+		limiterErr       error
+		expectedCalls    []string
+		expectedRequests int
+		expectErr        bool
+	}{
+		{
+			name:             "Waits on the limiter before every attempt",
+			expectedCalls:    []string{"testApiKey /swap/v6.1/1/quote", "testApiKey /swap/v6.1/1/quote"},
+			expectedRequests: 2,
+		},
+		{
+			name:          "Limiter error aborts the request",
+			limiterErr:    context.Canceled,
+			expectedCalls: []string{"testApiKey /swap/v6.1/1/quote"},
+			expectErr:     true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requests := 0
+			mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if requests == 1 {
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer mockServer.Close()
+
+			limiter := &recordingRateLimiter{err: tc.limiterErr}
+			client := Client{
+				httpClient:  *mockServer.Client(),
+				baseURL:     mustParseURL(mockServer.URL),
+				apiKey:      "testApiKey",
+				retryPolicy: &common.RetryPolicy{MaxAttempts: 2},
+				rateLimiter: limiter,
+			}
+
+			err := client.ExecuteRequest(context.Background(), common.RequestPayload{Method: "GET", U: "/swap/v6.1/1/quote"}, nil)
+			if tc.expectErr {
+				require.ErrorIs(t, err, tc.limiterErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedCalls, limiter.calls)
+			assert.Equal(t, tc.expectedRequests, requests)
+		})
+	}
+}
+
+func TestExecuteRequest_Middleware(t *testing.T) {
+	tests := []struct {
+		name             string
+		middleware       common.Middleware
+		expectedTenantID string
+		expectErr        bool
+	}{
+		{
+			name: "Middleware can add headers",
+			middleware: func(next http.RoundTripper) http.RoundTripper {
+				return common.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					req = req.Clone(req.Context())
+					req.Header.Set("X-Tenant-Id", "acme")
+					return next.RoundTrip(req)
+				})
+			},
+			expectedTenantID: "acme",
+		},
+		{
+			name: "Middleware can inject faults",
+			middleware: func(next http.RoundTripper) http.RoundTripper {
+				return common.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					return nil, fmt.Errorf("injected fault")
+				})
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var tenantID, authorization string
+			mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				tenantID = r.Header.Get("X-Tenant-Id")
+				authorization = r.Header.Get("Authorization")
+				_, _ = io.WriteString(w, `{"result":"success"}`)
+			}))
+			defer mockServer.Close()
+
+			client, err := DefaultHttpClient(mockServer.URL, "testApiKey", common.WithMiddleware(tc.middleware))
+			require.NoError(t, err)
+
+			var result map[string]string
+			err = client.ExecuteRequest(context.Background(), common.RequestPayload{Method: "GET", U: "/test"}, &result)
+			if tc.expectErr {
+				require.ErrorContains(t, err, "injected fault")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedTenantID, tenantID)
+			assert.Equal(t, "Bearer testApiKey", authorization)
+			assert.Equal(t, "success", result["result"])
+		})
+	}
+}
+
+func TestDefaultHttpClient_Options(t *testing.T) {
+	customTransportUsed := false
+	customTransport := common.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		customTransportUsed = true
+		return http.DefaultTransport.RoundTrip(req)
+	})
+
+	tests := []struct {
+		name                  string
+		opts                  []common.HttpOption
+		serverDelay           time.Duration
+		expectCustomTransport bool
+		expectErr             bool
+	}{
+		{
+			name:                  "Custom http.Client is used",
+			opts:                  []common.HttpOption{common.WithHttpClient(&http.Client{Transport: customTransport})},
+			expectCustomTransport: true,
+		},
+		{
+			name:                  "Custom transport is used",
+			opts:                  []common.HttpOption{common.WithTransport(customTransport)},
+			expectCustomTransport: true,
+		},
+		{
+			name:        "Timeout aborts slow attempts",
+			opts:        []common.HttpOption{common.WithTimeout(10 * time.Millisecond)},
+			serverDelay: 200 * time.Millisecond,
+			expectErr:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			customTransportUsed = false
+			mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(tc.serverDelay)
+				_, _ = io.WriteString(w, `{}`)
+			}))
+			defer mockServer.Close()
+
+			client, err := DefaultHttpClient(mockServer.URL, "testApiKey", tc.opts...)
+			require.NoError(t, err)
+
+			err = client.ExecuteRequest(context.Background(), common.RequestPayload{Method: "GET", U: "/test"}, nil)
+			if tc.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectCustomTransport, customTransportUsed)
+		})
+	}
+}
+
+func TestNewExecutor(t *testing.T) {
+	custom := &Client{}
+
+	tests := []struct {
+		name           string
+		opts           []common.HttpOption
+		expectedCustom bool
+	}{
+		{
+			name: "Default client without options",
+		},
+		{
+			name:           "Custom executor replaces the default client",
+			opts:           []common.HttpOption{common.WithRetryPolicy(common.DefaultRetryPolicy()), common.WithExecutor(custom)},
+			expectedCustom: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			executor, err := NewExecutor("https://api.example.com", "testApiKey", tc.opts...)
+			require.NoError(t, err)
+			if tc.expectedCustom {
+				assert.Same(t, custom, executor)
+			} else {
+				assert.NotSame(t, custom, executor)
+				assert.IsType(t, &Client{}, executor)
+			}
+		})
+	}
+}
+
+func TestExecuteRequest_Telemetry(t *testing.T) {
+	attempts := 0
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = io.WriteString(w, `{}`)
+	}))
+	defer mockServer.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	client, err := DefaultHttpClient(mockServer.URL, "secretApiKey",
+		common.WithRetryPolicy(common.RetryPolicy{MaxAttempts: 2}),
+		common.WithTelemetry(common.Telemetry{
+			TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)),
+		}))
+	require.NoError(t, err)
+
+	err = client.ExecuteRequest(context.Background(), common.RequestPayload{Method: "GET", U: "/swap/v6.0/1/quote"}, nil)
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "GET /swap/v6.0/{chainId}/quote", spans[0].Name())
+
+	attrs := make(map[string]string)
+	for _, kv := range spans[0].Attributes() {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+		assert.NotContains(t, kv.Value.Emit(), "secretApiKey")
+	}
+	assert.Equal(t, "200", attrs["http.response.status_code"])
+	assert.Equal(t, "1", attrs["http.request.resend_count"])
+	assert.Equal(t, "1", attrs["oneinch.chain_id"])
+}
+
+func TestExecuteRequest_Logger(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{}`)
+	}))
+	defer mockServer.Close()
+
+	tests := []struct {
+		name        string
+		level       slog.Level
+		expectEmpty bool
+	}{
+		{
+			name:  "Debug level logs the request",
+			level: slog.LevelDebug,
+		},
+		{
+			name:        "Info level logs nothing",
+			level:       slog.LevelInfo,
+			expectEmpty: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: tc.level}))
+			client, err := DefaultHttpClient(mockServer.URL, "secretApiKey", common.WithLogger(logger))
+			require.NoError(t, err)
+
+			params := struct {
+				Amount    string `url:"amount"`
+				Signature string `url:"signature"`
+			}{Amount: "100", Signature: "0xdeadbeef"}
+			err = client.ExecuteRequest(context.Background(), common.RequestPayload{Method: "GET", U: "/swap/v6.0/1/quote", Params: params}, nil)
+			require.NoError(t, err)
+
+			if tc.expectEmpty {
+				assert.Empty(t, buf.String())
+				return
+			}
+			output := buf.String()
+			assert.Contains(t, output, `"endpoint":"/swap/v6.0/{chainId}/quote"`)
+			assert.Contains(t, output, `"status":200`)
+			assert.Contains(t, output, `"amount":"100"`)
+			assert.Contains(t, output, `"signature":"[REDACTED]"`)
+			assert.NotContains(t, output, "secretApiKey")
+			assert.NotContains(t, output, "0xdeadbeef")
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package http_executor
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+)
+
+// defaultMaxRetryAfter is the longest Retry-After honored when the policy sets no limit
+const defaultMaxRetryAfter = time.Minute
+
+// maxAttempts returns the number of attempts allowed for method under policy.
+func maxAttempts(policy *common.RetryPolicy, method string) int {
+	if policy == nil || policy.MaxAttempts < 2 {
+		return 1
+	}
+	if !policy.RetryNonIdempotent && !isIdempotent(method) {
+		return 1
+	}
+	return policy.MaxAttempts
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+// isRetryableStatus reports whether a response status is worth retrying.
+// 501 Not Implemented is excluded because it never succeeds on a second try.
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests ||
+		(status >= http.StatusInternalServerError && status != http.StatusNotImplemented)
+}
+
+// isRetryableError reports whether a transport error is worth retrying.
+// Errors caused by the caller's context are final.
+func isRetryableError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// backoff returns the jittered exponential wait before retry number retry (starting at 1).
+// The result lies in [d/2, d], where d is the doubled initial backoff capped at MaxBackoff.
+func backoff(policy *common.RetryPolicy, retry int) time.Duration {
+	d := policy.InitialBackoff
+	for i := 1; i < retry && (policy.MaxBackoff <= 0 || d < policy.MaxBackoff); i++ {
+		d *= 2
+	}
+	if policy.MaxBackoff > 0 && d > policy.MaxBackoff {
+		d = policy.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + rand.N(d-half+1)
+}
+
+// maxRetryAfter returns the longest Retry-After worth waiting for under policy.
+func maxRetryAfter(policy *common.RetryPolicy) time.Duration {
+	if policy.MaxRetryAfter > 0 {
+		return policy.MaxRetryAfter
+	}
+	return defaultMaxRetryAfter
+}
+
+// parseRetryAfter reads a Retry-After header given either as delay seconds or as an HTTP date.
+func parseRetryAfter(header string, now time.Time) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(header); err == nil {
+		if d := at.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// sleep waits for d or until ctx is done, whichever comes first.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,288 @@
+package http_executor
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+)
+
+func testRetryPolicy() *common.RetryPolicy {
+	return &common.RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+	}
+}
+
+func TestExecuteRequest_Retry(t *testing.T) {
+	tests := []struct {
+		name             string
+		method           string
+		policy           *common.RetryPolicy
+		statuses         []int
+		retryAfter       string
+		expectedAttempts int32
+		expectErr        bool
+	}{
+		{
+			name:             "Retries 429 until success",
+			method:           http.MethodGet,
+			policy:           testRetryPolicy(),
+			statuses:         []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusOK},
+			expectedAttempts: 3,
+		},
+		{
+			name:             "Retries 5xx until success",
+			method:           http.MethodGet,
+			policy:           testRetryPolicy(),
+			statuses:         []int{http.StatusBadGateway, http.StatusOK},
+			expectedAttempts: 2,
+		},
+		{
+			name:             "Gives up after max attempts",
+			method:           http.MethodGet,
+			policy:           testRetryPolicy(),
+			statuses:         []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK},
+			expectedAttempts: 3,
+			expectErr:        true,
+		},
+		{
+			name:             "Does not retry 4xx other than 429",
+			method:           http.MethodGet,
+			policy:           testRetryPolicy(),
+			statuses:         []int{http.StatusBadRequest, http.StatusOK},
+			expectedAttempts: 1,
+			expectErr:        true,
+		},
+		{
+			name:             "Does not retry POST by default",
+			method:           http.MethodPost,
+			policy:           testRetryPolicy(),
+			statuses:         []int{http.StatusTooManyRequests, http.StatusOK},
+			expectedAttempts: 1,
+			expectErr:        true,
+		},
+		{
+			name:   "Retries POST when opted in",
+			method: http.MethodPost,
+			policy: &common.RetryPolicy{
+				MaxAttempts:        2,
+				InitialBackoff:     time.Millisecond,
+				RetryNonIdempotent: true,
+			},
+			statuses:         []int{http.StatusTooManyRequests, http.StatusOK},
+			expectedAttempts: 2,
+		},
+		{
+			name:             "No policy means a single attempt",
+			method:           http.MethodGet,
+			statuses:         []int{http.StatusTooManyRequests, http.StatusOK},
+			expectedAttempts: 1,
+			expectErr:        true,
+		},
+		{
+			name:             "Honors a zero Retry-After",
+			method:           http.MethodGet,
+			policy:           &common.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Hour},
+			statuses:         []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter:       "0",
+			expectedAttempts: 2,
+		},
+		{
+			name:             "Gives up on a Retry-After above the default limit",
+			method:           http.MethodGet,
+			policy:           testRetryPolicy(),
+			statuses:         []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter:       "86400",
+			expectedAttempts: 1,
+			expectErr:        true,
+		},
+		{
+			name:             "Gives up on a Retry-After date above the limit",
+			method:           http.MethodGet,
+			policy:           &common.RetryPolicy{MaxAttempts: 2, MaxRetryAfter: time.Second},
+			statuses:         []int{http.StatusServiceUnavailable, http.StatusOK},
+			retryAfter:       "Wed, 21 Oct 2099 07:28:00 GMT",
+			expectedAttempts: 1,
+			expectErr:        true,
+		},
+		{
+			name:             "Waits for a Retry-After within the limit",
+			method:           http.MethodGet,
+			policy:           &common.RetryPolicy{MaxAttempts: 2, MaxRetryAfter: time.Second},
+			statuses:         []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter:       "1",
+			expectedAttempts: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := attempts.Add(1)
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				if r.Method == http.MethodPost {
+					assert.Equal(t, `{"key":"value"}`, string(body))
+				}
+				if tc.retryAfter != "" {
+					w.Header().Set("Retry-After", tc.retryAfter)
+				}
+				w.WriteHeader(tc.statuses[n-1])
+				_, _ = io.WriteString(w, `{"result":"success"}`)
+			}))
+			defer mockServer.Close()
+
+			client := Client{
+				httpClient:  *mockServer.Client(),
+				baseURL:     mustParseURL(mockServer.URL),
+				apiKey:      "testApiKey",
+				retryPolicy: tc.policy,
+			}
+
+			payload := common.RequestPayload{
+				Method: tc.method,
+				U:      "/test",
+			}
+			if tc.method == http.MethodPost {
+				payload.Body = []byte(`{"key":"value"}`)
+			}
+
+			var result map[string]string
+			err := client.ExecuteRequest(context.Background(), payload, &result)
+			if tc.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "success", result["result"])
+			}
+			assert.Equal(t, tc.expectedAttempts, attempts.Load())
+		})
+	}
+}
+
+func TestExecuteRequest_RetryStopsOnContextCancel(t *testing.T) {
+	var attempts atomic.Int32
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer mockServer.Close()
+
+	client := Client{
+		httpClient:  *mockServer.Client(),
+		baseURL:     mustParseURL(mockServer.URL),
+		apiKey:      "testApiKey",
+		retryPolicy: testRetryPolicy(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := client.ExecuteRequest(ctx, common.RequestPayload{Method: http.MethodGet, U: "/test"}, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(1), attempts.Load())
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		header        string
+		expected      time.Duration
+		expectedFound bool
+	}{
+		{
+			name:          "Delay seconds",
+			header:        "7",
+			expected:      7 * time.Second,
+			expectedFound: true,
+		},
+		{
+			name:          "HTTP date in the future",
+			header:        now.Add(30 * time.Second).Format(http.TimeFormat),
+			expected:      30 * time.Second,
+			expectedFound: true,
+		},
+		{
+			name:          "HTTP date in the past",
+			header:        now.Add(-30 * time.Second).Format(http.TimeFormat),
+			expected:      0,
+			expectedFound: true,
+		},
+		{
+			name:          "Missing header",
+			header:        "",
+			expectedFound: false,
+		},
+		{
+			name:          "Negative seconds",
+			header:        "-1",
+			expectedFound: false,
+		},
+		{
+			name:          "Garbage",
+			header:        "soon",
+			expectedFound: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d, ok := parseRetryAfter(tc.header, now)
+			assert.Equal(t, tc.expectedFound, ok)
+			assert.Equal(t, tc.expected, d)
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	policy := &common.RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+	}
+
+	tests := []struct {
+		name  string
+		retry int
+		upper time.Duration
+	}{
+		{
+			name:  "First retry uses the initial backoff",
+			retry: 1,
+			upper: 100 * time.Millisecond,
+		},
+		{
+			name:  "Third retry doubles twice",
+			retry: 3,
+			upper: 400 * time.Millisecond,
+		},
+		{
+			name:  "Backoff is capped",
+			retry: 20,
+			upper: time.Second,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for i := 0; i < 50; i++ {
+				d := backoff(policy, tc.retry)
+				assert.GreaterOrEqual(t, d, tc.upper/2)
+				assert.LessOrEqual(t, d, tc.upper)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+// RedactedValue replaces the value of every attribute that may hold a secret.
+const RedactedValue = "[REDACTED]"
+
+// sensitiveSuffixes lists normalized attribute key suffixes whose values are always redacted.
+var sensitiveSuffixes = []string{
+	"apikey",
+	"privatekey",
+	"signature",
+	"authorization",
+	"permit",
+	"secret",
+	"password",
+	"passphrase",
+	"mnemonic",
+}
+
+// New wraps l so that attributes which may carry secrets, such as API keys, private keys
+// and signatures, are always redacted. A nil l yields a logger that discards everything.
+func New(l *slog.Logger) *slog.Logger {
+	if l == nil {
+		return slog.New(slog.DiscardHandler)
+	}
+	if _, ok := l.Handler().(*redactingHandler); ok {
+		return l
+	}
+	return slog.New(&redactingHandler{next: l.Handler()})
+}
+
+// Debug logs at debug level on l. It is a no-op when l is nil, so structs built without
+// a logger, such as in tests, need no special handling.
+func Debug(ctx context.Context, l *slog.Logger, msg string, args ...any) {
+	if l == nil {
+		return
+	}
+	l.DebugContext(ctx, msg, args...)
+}
+
+// IsSensitive reports whether an attribute named key must be redacted.
+func IsSensitive(key string) bool {
+	normalized := strings.NewReplacer("_", "", "-", "", ".", "").Replace(strings.ToLower(key))
+	for _, suffix := range sensitiveSuffixes {
+		if strings.HasSuffix(normalized, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactingHandler redacts sensitive attributes before passing records to the next handler.
+type redactingHandler struct {
+	next slog.Handler
+}
+
+func (h *redactingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *redactingHandler) Handle(ctx context.Context, r slog.Record) error {
+	redacted := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		redacted.AddAttrs(redact(a))
+		return true
+	})
+	return h.next.Handle(ctx, redacted)
+}
+
+func (h *redactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = redact(a)
+	}
+	return &redactingHandler{next: h.next.WithAttrs(redacted)}
+}
+
+func (h *redactingHandler) WithGroup(name string) slog.Handler {
+	return &redactingHandler{next: h.next.WithGroup(name)}
+}
+
+func redact(a slog.Attr) slog.Attr {
+	a.Value = a.Value.Resolve()
+	if IsSensitive(a.Key) {
+		return slog.String(a.Key, RedactedValue)
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		group := a.Value.Group()
+		redacted := make([]any, len(group))
+		for i, ga := range group {
+			redacted[i] = redact(ga)
+		}
+		return slog.Group(a.Key, redacted...)
+	}
+	return a
+}
@@ -0,0 +1,100 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSensitive(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		expected bool
+	}{
+		{name: "API key", key: "apiKey", expected: true},
+		{name: "Snake case private key", key: "private_key", expected: true},
+		{name: "Prefixed signature", key: "permitSignature", expected: true},
+		{name: "Authorization header", key: "Authorization", expected: true},
+		{name: "Fusion plus secret", key: "secret", expected: true},
+		{name: "Mnemonic", key: "wallet.mnemonic", expected: true},
+		{name: "Order hash", key: "orderHash", expected: false},
+		{name: "Amount", key: "amount", expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsSensitive(tc.key))
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name     string
+		log      func(l *slog.Logger)
+		contains []string
+		excludes []string
+	}{
+		{
+			name: "Redacts top level attributes",
+			log: func(l *slog.Logger) {
+				l.Debug("msg", slog.String("apiKey", "key123"), slog.String("orderHash", "0xabc"))
+			},
+			contains: []string{`"apiKey":"[REDACTED]"`, `"orderHash":"0xabc"`},
+			excludes: []string{"key123"},
+		},
+		{
+			name: "Redacts attributes inside groups",
+			log: func(l *slog.Logger) {
+				l.Debug("msg", slog.Group("query", slog.String("signature", "0xsig"), slog.String("amount", "1")))
+			},
+			contains: []string{`"query":{"signature":"[REDACTED]","amount":"1"}`},
+			excludes: []string{"0xsig"},
+		},
+		{
+			name: "Redacts attributes added with With",
+			log: func(l *slog.Logger) {
+				l.With("privateKey", "965e").Debug("msg")
+			},
+			contains: []string{`"privateKey":"[REDACTED]"`},
+			excludes: []string{"965e"},
+		},
+		{
+			name: "Redacts whole groups with sensitive names",
+			log: func(l *slog.Logger) {
+				l.Debug("msg", slog.Group("permit", slog.String("value", "0x01")))
+			},
+			contains: []string{`"permit":"[REDACTED]"`},
+			excludes: []string{"0x01"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			l := New(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+			tc.log(l)
+			for _, s := range tc.contains {
+				assert.Contains(t, buf.String(), s)
+			}
+			for _, s := range tc.excludes {
+				assert.NotContains(t, buf.String(), s)
+			}
+		})
+	}
+}
+
+func TestNewNilLogger(t *testing.T) {
+	l := New(nil)
+	assert.False(t, l.Enabled(context.Background(), slog.LevelError))
+	Debug(context.Background(), nil, "does not panic")
+}
+
+func TestNewIsIdempotent(t *testing.T) {
+	l := New(slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)))
+	assert.Same(t, l, New(l))
+}
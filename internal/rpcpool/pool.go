@@ -0,0 +1,383 @@
+// Package rpcpool spreads the JSON-RPC requests of one client over several node endpoints. It
+// is an http.RoundTripper, so the wallet's ethclient keeps working unchanged on top of it.
+package rpcpool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+)
+
+const defaultHealthCheckInterval = 30 * time.Second
+
+const (
+	// probeTimeout bounds a background health check
+	probeTimeout = 5 * time.Second
+	// broadcastTimeout bounds the sends of a broadcast, which continue after the caller returns
+	broadcastTimeout = 30 * time.Second
+)
+
+const broadcastMethod = "eth_sendRawTransaction"
+
+var defaultQuorumMethods = []string{"eth_getTransactionCount", "eth_call"}
+
+// ErrNoQuorum is returned when too few endpoints agree on the result of a quorum read.
+var ErrNoQuorum = errors.New("node endpoints do not agree")
+
+// Pool is an http.RoundTripper that sends each JSON-RPC request to one or more endpoints.
+type Pool struct {
+	endpoints     []*endpoint
+	base          http.RoundTripper
+	strategy      common.NodeStrategy
+	interval      time.Duration
+	quorum        int
+	quorumMethods []string
+	next          atomic.Uint64
+	now           func() time.Time
+}
+
+type endpoint struct {
+	url *url.URL
+
+	mu sync.Mutex
+	// failedAt is the time of the last failure, zero while the endpoint is healthy
+	failedAt time.Time
+}
+
+// New returns a pool over urls, which must all be HTTP(S). base sends the requests; nil uses
+// http.DefaultTransport.
+func New(urls []string, policy common.NodePolicy, base http.RoundTripper) (*Pool, error) {
+	if len(urls) == 0 {
+		return nil, errors.New("at least one node URL is required")
+	}
+	if policy.Quorum > len(urls) {
+		return nil, fmt.Errorf("quorum of %d needs at least as many node URLs, got %d", policy.Quorum, len(urls))
+	}
+	p := &Pool{
+		base:          base,
+		strategy:      policy.Strategy,
+		interval:      policy.HealthCheckInterval,
+		quorum:        policy.Quorum,
+		quorumMethods: policy.QuorumMethods,
+		now:           time.Now,
+	}
+	if p.base == nil {
+		p.base = http.DefaultTransport
+	}
+	if p.interval <= 0 {
+		p.interval = defaultHealthCheckInterval
+	}
+	if p.quorumMethods == nil {
+		p.quorumMethods = defaultQuorumMethods
+	}
+	for _, raw := range urls {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid node URL %q: %w", raw, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, fmt.Errorf("node URL %q: only http and https endpoints can be combined", raw)
+		}
+		p.endpoints = append(p.endpoints, &endpoint{url: u})
+	}
+	return p, nil
+}
+
+// Healthy returns the URLs of the endpoints that have not failed recently.
+func (p *Pool) Healthy() []string {
+	var healthy []string
+	for _, e := range p.endpoints {
+		if e.healthy() {
+			healthy = append(healthy, e.url.String())
+		}
+	}
+	return healthy
+}
+
+func (p *Pool) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	method := rpcMethod(body)
+	switch {
+	case method == broadcastMethod:
+		return p.broadcast(req, body)
+	case p.quorum > 1 && slices.Contains(p.quorumMethods, method):
+		return p.quorumRead(req, body)
+	default:
+		return p.failover(req, body)
+	}
+}
+
+// failover tries the endpoints in the order of the strategy until one answers
+func (p *Pool) failover(req *http.Request, body []byte) (*http.Response, error) {
+	var errs []error
+	for _, e := range p.order() {
+		resp, err := p.send(req, body, e)
+		if err == nil {
+			return resp, nil
+		}
+		errs = append(errs, err)
+		if req.Context().Err() != nil {
+			break
+		}
+	}
+	return nil, errors.Join(errs...)
+}
+
+// broadcast sends the request to every endpoint at once and returns the first answer without
+// a JSON-RPC error, so a transaction reaches the network even when some nodes are down. The
+// other endpoints keep receiving the transaction after broadcast returns, so the sends run on
+// a context detached from the caller's.
+func (p *Pool) broadcast(req *http.Request, body []byte) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(req.Context()), broadcastTimeout)
+	answers := p.sendEach(req.WithContext(ctx), body, p.endpoints)
+	drain := func() {
+		for range answers {
+		}
+		cancel()
+	}
+
+	var fallback *answer
+	var errs []error
+	for {
+		select {
+		case a, ok := <-answers:
+			switch {
+			case !ok:
+				cancel()
+				if fallback != nil {
+					return fallback.response(), nil
+				}
+				return nil, errors.Join(errs...)
+			case a.err != nil:
+				errs = append(errs, a.err)
+			case a.rpcErr == nil:
+				go drain()
+				return a.response(), nil
+			case fallback == nil:
+				fallback = &a
+			}
+		case <-req.Context().Done():
+			go drain()
+			return nil, req.Context().Err()
+		}
+	}
+}
+
+// quorumRead asks the endpoints in the order of the strategy at once and succeeds as soon as
+// the quorum returns the same result, cancelling the requests still running
+func (p *Pool) quorumRead(req *http.Request, body []byte) (*http.Response, error) {
+	ctx, cancel := context.WithCancel(req.Context())
+	defer cancel()
+	endpoints := p.order()
+	votes := make(map[string]int)
+	var results []string
+	for a := range p.sendEach(req.WithContext(ctx), body, endpoints) {
+		if a.err != nil || a.rpcErr != nil {
+			continue
+		}
+		key := string(a.result)
+		votes[key]++
+		results = append(results, key)
+		if votes[key] >= p.quorum {
+			return a.response(), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %d of %d endpoints answered, results %v, quorum is %d",
+		ErrNoQuorum, len(results), len(endpoints), results, p.quorum)
+}
+
+// order returns the endpoints to try: healthy ones first, in the strategy's order, then the
+// unhealthy ones as a last resort
+func (p *Pool) order() []*endpoint {
+	n := len(p.endpoints)
+	start := 0
+	if p.strategy == common.NodeRoundRobin {
+		start = int((p.next.Add(1) - 1) % uint64(n))
+	}
+	var healthy, unhealthy []*endpoint
+	for i := 0; i < n; i++ {
+		e := p.endpoints[(start+i)%n]
+		if e.healthy() {
+			healthy = append(healthy, e)
+		} else {
+			p.probeIfDue(e)
+			unhealthy = append(unhealthy, e)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+// probeIfDue starts a background health check of an endpoint that failed more than the health
+// check interval ago. The endpoint stays unhealthy until the check succeeds, so requests never
+// wait for it.
+func (p *Pool) probeIfDue(e *endpoint) {
+	e.mu.Lock()
+	due := !e.failedAt.IsZero() && p.now().Sub(e.failedAt) >= p.interval
+	if due {
+		// Other requests do not probe the endpoint again while it is probed
+		e.failedAt = p.now()
+	}
+	e.mu.Unlock()
+	if due {
+		go p.probe(e)
+	}
+}
+
+// probe sends eth_blockNumber to e, which marks it healthy when it answers
+func (p *Pool) probe(e *endpoint) {
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url.String(),
+		strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber","params":[]}`))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := p.send(req, nil, e)
+	if err != nil {
+		return
+	}
+	_ = resp.Body.Close()
+}
+
+// send sends body to e. Network errors and HTTP 429 and 5xx responses mark e unhealthy and
+// are returned as errors; any other response marks it healthy. A request cancelled by its
+// context says nothing about the endpoint and leaves its health unchanged.
+func (p *Pool) send(req *http.Request, body []byte, e *endpoint) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	out.URL = e.url
+	out.Host = e.url.Host
+	if body != nil {
+		out.Body = io.NopCloser(bytes.NewReader(body))
+		out.ContentLength = int64(len(body))
+		out.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(body)), nil }
+	}
+
+	resp, err := p.base.RoundTrip(out)
+	if err == nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500) {
+		_ = resp.Body.Close()
+		err = fmt.Errorf("status %d", resp.StatusCode)
+	}
+	if err != nil {
+		if !errors.Is(out.Context().Err(), context.Canceled) {
+			e.fail(p.now())
+		}
+		return nil, fmt.Errorf("node %s: %w", e.url.Host, err)
+	}
+	e.succeed()
+	return resp, nil
+}
+
+// answer is a buffered response of one endpoint
+type answer struct {
+	status int
+	header http.Header
+	body   []byte
+	result json.RawMessage
+	rpcErr json.RawMessage
+	err    error
+}
+
+func (a *answer) response() *http.Response {
+	return &http.Response{
+		StatusCode:    a.status,
+		Header:        a.header,
+		Body:          io.NopCloser(bytes.NewReader(a.body)),
+		ContentLength: int64(len(a.body)),
+	}
+}
+
+// sendEach sends the request to endpoints concurrently and delivers their answers as they
+// arrive. The channel is closed after the last answer.
+func (p *Pool) sendEach(req *http.Request, body []byte, endpoints []*endpoint) <-chan answer {
+	answers := make(chan answer, len(endpoints))
+	var wg sync.WaitGroup
+	for _, e := range endpoints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			answers <- p.ask(req, body, e)
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(answers)
+	}()
+	return answers
+}
+
+// ask sends the request to e and buffers its answer
+func (p *Pool) ask(req *http.Request, body []byte, e *endpoint) answer {
+	var a answer
+	resp, err := p.send(req, body, e)
+	if err != nil {
+		a.err = err
+		return a
+	}
+	defer resp.Body.Close()
+	a.status, a.header = resp.StatusCode, resp.Header
+	if a.body, a.err = io.ReadAll(resp.Body); a.err != nil {
+		return a
+	}
+	var msg struct {
+		Result json.RawMessage `json:"result"`
+		Error  json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(a.body, &msg); err != nil {
+		a.err = fmt.Errorf("node %s: invalid response: %w", e.url.Host, err)
+		return a
+	}
+	a.result, a.rpcErr = msg.Result, msg.Error
+	return a
+}
+
+func (e *endpoint) healthy() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.failedAt.IsZero()
+}
+
+func (e *endpoint) fail(now time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.failedAt = now
+}
+
+func (e *endpoint) succeed() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.failedAt = time.Time{}
+}
+
+// rpcMethod returns the method of a single JSON-RPC request, or "" for batches and bodies
+// that are not JSON-RPC
+func rpcMethod(body []byte) string {
+	var msg struct {
+		Method string `json:"method"`
+	}
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return ""
+	}
+	return msg.Method
+}
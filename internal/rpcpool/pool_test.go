@@ -0,0 +1,440 @@
+package rpcpool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+)
+
+// fakeNode answers every JSON-RPC request with result, or fails with status when it is set
+type fakeNode struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	status  int
+	result  string
+	rpcErr  string
+	methods []string
+	// release, when set, holds every request until it is closed
+	release chan struct{}
+}
+
+func newFakeNode(t *testing.T, result string) *fakeNode {
+	n := &fakeNode{result: result}
+	n.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		n.mu.Lock()
+		n.methods = append(n.methods, req.Method)
+		release := n.release
+		n.mu.Unlock()
+		if release != nil {
+			<-release
+		}
+
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		if n.status != 0 {
+			w.WriteHeader(n.status)
+			return
+		}
+		if n.rpcErr != "" {
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"error":{"code":-32000,"message":%q}}`, req.ID, n.rpcErr)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":%q}`, req.ID, n.result)
+	}))
+	t.Cleanup(n.Close)
+	return n
+}
+
+func (n *fakeNode) set(status int, result string, rpcErr string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.status, n.result, n.rpcErr = status, result, rpcErr
+}
+
+// hold delays every request until the returned function is called
+func (n *fakeNode) hold() func() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.release = make(chan struct{})
+	return sync.OnceFunc(func() { close(n.release) })
+}
+
+func (n *fakeNode) calls() []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]string(nil), n.methods...)
+}
+
+func call(t *testing.T, p *Pool, method string) (string, error) {
+	req, err := http.NewRequest(http.MethodPost, "http://placeholder",
+		strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"`+method+`","params":[]}`))
+	require.NoError(t, err)
+	resp, err := p.RoundTrip(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var msg struct {
+		Result string `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(body, &msg))
+	if msg.Error != nil {
+		return "", errors.New(msg.Error.Message)
+	}
+	return msg.Result, nil
+}
+
+func TestFailover(t *testing.T) {
+	tests := []struct {
+		name            string
+		strategy        common.NodeStrategy
+		downFirst       bool
+		requests        int
+		expectedResults []string
+		expectedCalls   [2]int
+	}{
+		{
+			name:            "Priority uses the first endpoint",
+			strategy:        common.NodePriority,
+			requests:        3,
+			expectedResults: []string{"0xa", "0xa", "0xa"},
+			expectedCalls:   [2]int{3, 0},
+		},
+		{
+			name:            "Priority fails over and skips the failed endpoint",
+			strategy:        common.NodePriority,
+			downFirst:       true,
+			requests:        3,
+			expectedResults: []string{"0xb", "0xb", "0xb"},
+			expectedCalls:   [2]int{1, 3},
+		},
+		{
+			name:            "Round robin alternates",
+			strategy:        common.NodeRoundRobin,
+			requests:        4,
+			expectedResults: []string{"0xa", "0xb", "0xa", "0xb"},
+			expectedCalls:   [2]int{2, 2},
+		},
+		{
+			name:            "Round robin skips the failed endpoint",
+			strategy:        common.NodeRoundRobin,
+			downFirst:       true,
+			requests:        4,
+			expectedResults: []string{"0xb", "0xb", "0xb", "0xb"},
+			expectedCalls:   [2]int{1, 4},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			a, b := newFakeNode(t, "0xa"), newFakeNode(t, "0xb")
+			if tc.downFirst {
+				a.set(http.StatusBadGateway, "", "")
+			}
+			p, err := New([]string{a.URL, b.URL}, common.NodePolicy{Strategy: tc.strategy}, nil)
+			require.NoError(t, err)
+
+			var results []string
+			for i := 0; i < tc.requests; i++ {
+				result, err := call(t, p, "eth_blockNumber")
+				require.NoError(t, err)
+				results = append(results, result)
+			}
+			assert.Equal(t, tc.expectedResults, results)
+			assert.Equal(t, tc.expectedCalls, [2]int{len(a.calls()), len(b.calls())})
+		})
+	}
+}
+
+func TestFailoverAllDown(t *testing.T) {
+	a, b := newFakeNode(t, "0xa"), newFakeNode(t, "0xb")
+	a.set(http.StatusServiceUnavailable, "", "")
+	b.set(http.StatusTooManyRequests, "", "")
+	p, err := New([]string{a.URL, b.URL}, common.NodePolicy{}, nil)
+	require.NoError(t, err)
+
+	_, err = call(t, p, "eth_chainId")
+	require.ErrorContains(t, err, "status 503")
+	require.ErrorContains(t, err, "status 429")
+	assert.Empty(t, p.Healthy())
+
+	// Failed endpoints are still tried as a last resort
+	b.set(0, "0x1", "")
+	result, err := call(t, p, "eth_chainId")
+	require.NoError(t, err)
+	assert.Equal(t, "0x1", result)
+	assert.Equal(t, []string{b.URL}, p.Healthy())
+}
+
+func TestHealthCheck(t *testing.T) {
+	a, b := newFakeNode(t, "0xa"), newFakeNode(t, "0xb")
+	a.set(http.StatusBadGateway, "", "")
+	p, err := New([]string{a.URL, b.URL}, common.NodePolicy{HealthCheckInterval: time.Minute}, nil)
+	require.NoError(t, err)
+	now := time.Now()
+	p.now = func() time.Time { return now }
+
+	result, err := call(t, p, "eth_chainId")
+	require.NoError(t, err)
+	assert.Equal(t, "0xb", result)
+
+	a.set(0, "0xa", "")
+	result, err = call(t, p, "eth_chainId")
+	require.NoError(t, err)
+	assert.Equal(t, "0xb", result, "the endpoint is skipped until the interval passes")
+
+	// The request that finds the interval passed starts a background probe and does not wait for it
+	now = now.Add(time.Minute)
+	result, err = call(t, p, "eth_chainId")
+	require.NoError(t, err)
+	assert.Equal(t, "0xb", result)
+	require.Eventually(t, func() bool { return len(p.Healthy()) == 2 }, time.Second, time.Millisecond)
+
+	result, err = call(t, p, "eth_chainId")
+	require.NoError(t, err)
+	assert.Equal(t, "0xa", result)
+	assert.Equal(t, []string{"eth_chainId", "eth_blockNumber", "eth_chainId"}, a.calls())
+}
+
+func TestBroadcast(t *testing.T) {
+	tests := []struct {
+		name           string
+		setup          func(a, b, c *fakeNode)
+		expectedResult string
+		expectedError  string
+	}{
+		{
+			name:           "Sent to every endpoint",
+			setup:          func(a, b, c *fakeNode) {},
+			expectedResult: "0xhash",
+		},
+		{
+			name: "Succeeds when any endpoint accepts it",
+			setup: func(a, b, c *fakeNode) {
+				a.set(http.StatusBadGateway, "", "")
+				b.set(0, "", "already known")
+			},
+			expectedResult: "0xhash",
+		},
+		{
+			name: "Returns the node error when no endpoint accepts it",
+			setup: func(a, b, c *fakeNode) {
+				a.set(http.StatusBadGateway, "", "")
+				b.set(0, "", "nonce too low")
+				c.set(0, "", "nonce too low")
+			},
+			expectedError: "nonce too low",
+		},
+		{
+			name: "All endpoints down",
+			setup: func(a, b, c *fakeNode) {
+				a.set(http.StatusBadGateway, "", "")
+				b.set(http.StatusBadGateway, "", "")
+				c.set(http.StatusBadGateway, "", "")
+			},
+			expectedError: "status 502",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			a, b, c := newFakeNode(t, "0xhash"), newFakeNode(t, "0xhash"), newFakeNode(t, "0xhash")
+			tc.setup(a, b, c)
+			p, err := New([]string{a.URL, b.URL, c.URL}, common.NodePolicy{}, nil)
+			require.NoError(t, err)
+
+			result, err := call(t, p, "eth_sendRawTransaction")
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+			}
+			for _, n := range []*fakeNode{a, b, c} {
+				assert.Eventually(t, func() bool { return len(n.calls()) == 1 }, time.Second, time.Millisecond)
+				assert.Equal(t, []string{"eth_sendRawTransaction"}, n.calls())
+			}
+		})
+	}
+}
+
+func TestBroadcastSlowEndpoint(t *testing.T) {
+	a, b := newFakeNode(t, "0xhash"), newFakeNode(t, "0xhash")
+	release := b.hold()
+	t.Cleanup(release)
+	p, err := New([]string{a.URL, b.URL}, common.NodePolicy{}, nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://placeholder",
+		strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"eth_sendRawTransaction","params":[]}`))
+	require.NoError(t, err)
+	resp, err := p.RoundTrip(req)
+	require.NoError(t, err, "the first accepting endpoint answers without waiting for the slow one")
+	_ = resp.Body.Close()
+
+	// The send to the slow endpoint is not cancelled with the caller's context, which would mark
+	// the endpoint unhealthy
+	cancel()
+	require.Eventually(t, func() bool { return len(b.calls()) == 1 }, time.Second, time.Millisecond)
+	assert.Never(t, func() bool { return len(p.Healthy()) != 2 }, 50*time.Millisecond, time.Millisecond)
+	release()
+}
+
+func TestQuorum(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		results        [3]string
+		downFirst      bool
+		expectedResult string
+		expectedError  error
+		expectedCalls  int
+	}{
+		{
+			name:           "Two of three agree",
+			method:         "eth_getTransactionCount",
+			results:        [3]string{"0x5", "0x4", "0x5"},
+			expectedResult: "0x5",
+			expectedCalls:  3,
+		},
+		{
+			name:           "Agreement despite a failed endpoint",
+			method:         "eth_call",
+			results:        [3]string{"0x1", "0x2", "0x2"},
+			downFirst:      true,
+			expectedResult: "0x2",
+			expectedCalls:  3,
+		},
+		{
+			name:          "No agreement",
+			method:        "eth_getTransactionCount",
+			results:       [3]string{"0x5", "0x4", "0x6"},
+			expectedError: ErrNoQuorum,
+			expectedCalls: 3,
+		},
+		{
+			name:           "Other methods read one endpoint",
+			method:         "eth_gasPrice",
+			results:        [3]string{"0x5", "0x4", "0x6"},
+			expectedResult: "0x5",
+			expectedCalls:  1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			nodes := []*fakeNode{newFakeNode(t, tc.results[0]), newFakeNode(t, tc.results[1]), newFakeNode(t, tc.results[2])}
+			if tc.downFirst {
+				nodes[0].set(http.StatusBadGateway, "", "")
+			}
+			p, err := New([]string{nodes[0].URL, nodes[1].URL, nodes[2].URL}, common.NodePolicy{Quorum: 2}, nil)
+			require.NoError(t, err)
+
+			result, err := call(t, p, tc.method)
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+			}
+			// A quorum read returns before the last endpoint has necessarily answered
+			assert.Eventually(t, func() bool {
+				calls := 0
+				for _, n := range nodes {
+					calls += len(n.calls())
+				}
+				return calls == tc.expectedCalls
+			}, time.Second, time.Millisecond)
+		})
+	}
+}
+
+func TestQuorumStalledEndpoint(t *testing.T) {
+	nodes := []*fakeNode{newFakeNode(t, "0x5"), newFakeNode(t, "0x5"), newFakeNode(t, "0x5")}
+	release := nodes[2].hold()
+	t.Cleanup(release)
+	p, err := New([]string{nodes[0].URL, nodes[1].URL, nodes[2].URL}, common.NodePolicy{Quorum: 2}, nil)
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		result, err := call(t, p, "eth_getTransactionCount")
+		assert.NoError(t, err)
+		assert.Equal(t, "0x5", result)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("quorum read waited for the stalled endpoint")
+	}
+
+	// The stalled request is cancelled, which does not count as a failure of the endpoint
+	assert.Never(t, func() bool { return len(p.Healthy()) != 3 }, 50*time.Millisecond, time.Millisecond)
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name          string
+		urls          []string
+		policy        common.NodePolicy
+		expectedError string
+	}{
+		{
+			name:          "No URLs",
+			expectedError: "at least one node URL is required",
+		},
+		{
+			name:          "Quorum larger than the pool",
+			urls:          []string{"http://a", "http://b"},
+			policy:        common.NodePolicy{Quorum: 3},
+			expectedError: "quorum of 3 needs at least as many node URLs, got 2",
+		},
+		{
+			name:          "WebSocket endpoint",
+			urls:          []string{"http://a", "wss://b"},
+			expectedError: `node URL "wss://b": only http and https endpoints can be combined`,
+		},
+		{
+			name: "Valid",
+			urls: []string{"http://a", "https://b"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := New(tc.urls, tc.policy, nil)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+	"github.com/1inch/1inch-sdk-go/v4/internal/version"
+)
+
+const instrumentationName = "github.com/1inch/1inch-sdk-go/v4"
+
+// Attribute keys recorded on spans and metrics. Standard keys follow the OpenTelemetry
+// semantic conventions; SDK-specific keys use the oneinch namespace.
+const (
+	keyHTTPMethod      = attribute.Key("http.request.method")
+	keyHTTPStatusCode  = attribute.Key("http.response.status_code")
+	keyHTTPResendCount = attribute.Key("http.request.resend_count")
+	keyURLTemplate     = attribute.Key("url.template")
+	keyErrorType       = attribute.Key("error.type")
+	keyRPCSystem       = attribute.Key("rpc.system")
+	keyRPCMethod       = attribute.Key("rpc.method")
+	keyChainID         = attribute.Key("oneinch.chain_id")
+)
+
+const (
+	apiDurationName     = "oneinch.sdk.api.request.duration"
+	rpcDurationName     = "oneinch.sdk.rpc.call.duration"
+	durationUnitSeconds = "s"
+)
+
+// Instruments records spans and duration metrics for API requests and RPC calls.
+// A nil *Instruments records nothing.
+type Instruments struct {
+	tracer      trace.Tracer
+	apiDuration metric.Float64Histogram
+	rpcDuration metric.Float64Histogram
+}
+
+// New creates instruments from the providers in t, falling back to the global
+// OpenTelemetry providers for any provider that is not set.
+func New(t *common.Telemetry) *Instruments {
+	tracerProvider := otel.GetTracerProvider()
+	meterProvider := otel.GetMeterProvider()
+	if t != nil && t.TracerProvider != nil {
+		tracerProvider = t.TracerProvider
+	}
+	if t != nil && t.MeterProvider != nil {
+		meterProvider = t.MeterProvider
+	}
+
+	meter := meterProvider.Meter(instrumentationName, metric.WithInstrumentationVersion(version.Version))
+	return &Instruments{
+		tracer: tracerProvider.Tracer(instrumentationName, trace.WithInstrumentationVersion(version.Version)),
+		apiDuration: histogram(meter, apiDurationName,
+			metric.WithDescription("Duration of 1inch API requests, including retries and rate limiter waits"),
+			metric.WithUnit(durationUnitSeconds)),
+		rpcDuration: histogram(meter, rpcDurationName,
+			metric.WithDescription("Duration of node RPC calls made by the wallet"),
+			metric.WithUnit(durationUnitSeconds)),
+	}
+}
+
+// histogram creates a histogram, falling back to a no-op one if the meter rejects it,
+// so that telemetry problems never break API calls.
+func histogram(meter metric.Meter, name string, opts ...metric.Float64HistogramOption) metric.Float64Histogram {
+	h, err := meter.Float64Histogram(name, opts...)
+	if err != nil {
+		otel.Handle(err)
+		h, _ = metricnoop.NewMeterProvider().Meter(instrumentationName).Float64Histogram(name)
+	}
+	return h
+}
+
+// APIRequest tracks a single API request, retries included.
+type APIRequest struct {
+	instruments *Instruments
+	span        trace.Span
+	start       time.Time
+	attrs       []attribute.KeyValue
+}
+
+// StartAPIRequest starts a client span for an API request to urlPath. The path is reduced to
+// a template so that addresses, hashes and numbers do not end up in span names or metrics.
+func (i *Instruments) StartAPIRequest(ctx context.Context, method string, urlPath string) (context.Context, *APIRequest) {
+	if i == nil {
+		return ctx, nil
+	}
+
+	template, chainID := EndpointTemplate(urlPath)
+	attrs := []attribute.KeyValue{
+		keyHTTPMethod.String(method),
+		keyURLTemplate.String(template),
+	}
+	if chainID != "" {
+		attrs = append(attrs, keyChainID.String(chainID))
+	}
+
+	ctx, span := i.tracer.Start(ctx, method+" "+template,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+
+	return ctx, &APIRequest{
+		instruments: i,
+		span:        span,
+		start:       time.Now(),
+		attrs:       attrs,
+	}
+}
+
+// End records the outcome of the request. statusCode is 0 when no response was received.
+func (r *APIRequest) End(statusCode int, retries int, err error) {
+	if r == nil {
+		return
+	}
+
+	attrs := r.attrs
+	if statusCode != 0 {
+		attrs = append(attrs, keyHTTPStatusCode.Int(statusCode))
+	}
+	switch {
+	case err != nil:
+		attrs = append(attrs, keyErrorType.String(errorType(err)))
+		r.span.RecordError(err)
+		r.span.SetStatus(codes.Error, err.Error())
+	case statusCode >= 400:
+		attrs = append(attrs, keyErrorType.String(strconv.Itoa(statusCode)))
+		r.span.SetStatus(codes.Error, "")
+	}
+
+	r.span.SetAttributes(attrs...)
+	r.span.SetAttributes(keyHTTPResendCount.Int(retries))
+	r.span.End()
+	r.instruments.apiDuration.Record(context.Background(), time.Since(r.start).Seconds(), metric.WithAttributes(attrs...))
+}
+
+// RPCCall tracks a single node RPC call.
+type RPCCall struct {
+	instruments *Instruments
+	span        trace.Span
+	start       time.Time
+	attrs       []attribute.KeyValue
+}
+
+// StartRPC starts a client span for the JSON-RPC method rpcMethod on chainID.
+func (i *Instruments) StartRPC(ctx context.Context, rpcMethod string, chainID int64) (context.Context, *RPCCall) {
+	if i == nil {
+		return ctx, nil
+	}
+
+	attrs := []attribute.KeyValue{
+		keyRPCSystem.String("jsonrpc"),
+		keyRPCMethod.String(rpcMethod),
+		keyChainID.Int64(chainID),
+	}
+	ctx, span := i.tracer.Start(ctx, rpcMethod,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+
+	return ctx, &RPCCall{
+		instruments: i,
+		span:        span,
+		start:       time.Now(),
+		attrs:       attrs,
+	}
+}
+
+// End records the outcome of the call.
+func (c *RPCCall) End(err error) {
+	if c == nil {
+		return
+	}
+
+	attrs := c.attrs
+	if err != nil {
+		attrs = append(attrs, keyErrorType.String(errorType(err)))
+		c.span.RecordError(err)
+		c.span.SetStatus(codes.Error, err.Error())
+	}
+	c.span.End()
+	c.instruments.rpcDuration.Record(context.Background(), time.Since(c.start).Seconds(), metric.WithAttributes(attrs...))
+}
+
+func errorType(err error) string {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	default:
+		return "_OTHER"
+	}
+}
+
+// EndpointTemplate replaces the variable segments of an API path with placeholders and
+// returns the chain ID, which 1inch APIs carry as the first numeric path segment.
+// For example "/swap/v6.0/1/quote" becomes "/swap/v6.0/{chainId}/quote" with chain ID "1".
+func EndpointTemplate(urlPath string) (template string, chainID string) {
+	segments := strings.Split(urlPath, "/")
+	for i, segment := range segments {
+		switch {
+		case segment == "":
+		case isDigits(segment) && chainID == "":
+			chainID = segment
+			segments[i] = "{chainId}"
+		case isDigits(segment):
+			segments[i] = "{number}"
+		case strings.HasPrefix(segment, "0x") && len(segment) == 42:
+			segments[i] = "{address}"
+		case strings.HasPrefix(segment, "0x"):
+			segments[i] = "{hash}"
+		}
+	}
+	return strings.Join(segments, "/"), chainID
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,184 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+)
+
+func newTestInstruments(t *testing.T) (*Instruments, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	instruments := New(&common.Telemetry{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)),
+		MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	})
+	return instruments, recorder, reader
+}
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func histogramCount(t *testing.T, reader *sdkmetric.ManualReader, name string) uint64 {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+			var count uint64
+			for _, dp := range m.Data.(metricdata.Histogram[float64]).DataPoints {
+				count += dp.Count
+			}
+			return count
+		}
+	}
+	return 0
+}
+
+func TestEndpointTemplate(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		expectedTemplate string
+		expectedChainID  string
+	}{
+		{
+			name:             "Chain ID segment",
+			path:             "/swap/v6.0/1/quote",
+			expectedTemplate: "/swap/v6.0/{chainId}/quote",
+			expectedChainID:  "1",
+		},
+		{
+			name:             "Address and hash segments",
+			path:             "/orderbook/v4.0/137/order/0x1234567890abcdef1234567890abcdef12345678901234567890abcdef123456",
+			expectedTemplate: "/orderbook/v4.0/{chainId}/order/{hash}",
+			expectedChainID:  "137",
+		},
+		{
+			name:             "Later numbers are not chain IDs",
+			path:             "/traces/v1.0/chain/1/block-trace/19000000",
+			expectedTemplate: "/traces/v1.0/chain/{chainId}/block-trace/{number}",
+			expectedChainID:  "1",
+		},
+		{
+			name:             "Wallet address",
+			path:             "/balance/v1.2/1/balances/0x1111111254eeb25477b68fb85ed929f73a960582",
+			expectedTemplate: "/balance/v1.2/{chainId}/balances/{address}",
+			expectedChainID:  "1",
+		},
+		{
+			name:             "No chain ID",
+			path:             "/fusion-plus/orders/v1.1/order/escrow",
+			expectedTemplate: "/fusion-plus/orders/v1.1/order/escrow",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			template, chainID := EndpointTemplate(tc.path)
+			assert.Equal(t, tc.expectedTemplate, template)
+			assert.Equal(t, tc.expectedChainID, chainID)
+		})
+	}
+}
+
+func TestAPIRequest(t *testing.T) {
+	tests := []struct {
+		name           string
+		statusCode     int
+		retries        int
+		err            error
+		expectedStatus codes.Code
+	}{
+		{
+			name:           "Successful request",
+			statusCode:     200,
+			expectedStatus: codes.Unset,
+		},
+		{
+			name:           "Failed status after retries",
+			statusCode:     429,
+			retries:        2,
+			expectedStatus: codes.Error,
+		},
+		{
+			name:           "Transport error",
+			err:            errors.New("connection refused"),
+			expectedStatus: codes.Error,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			instruments, recorder, reader := newTestInstruments(t)
+
+			_, request := instruments.StartAPIRequest(context.Background(), "GET", "/swap/v6.0/1/quote")
+			request.End(tc.statusCode, tc.retries, tc.err)
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, "GET /swap/v6.0/{chainId}/quote", spans[0].Name())
+			assert.Equal(t, tc.expectedStatus, spans[0].Status().Code)
+
+			attrs := spanAttributes(spans[0])
+			assert.Equal(t, "/swap/v6.0/{chainId}/quote", attrs[keyURLTemplate].AsString())
+			assert.Equal(t, "1", attrs[keyChainID].AsString())
+			assert.Equal(t, int64(tc.retries), attrs[keyHTTPResendCount].AsInt64())
+			if tc.statusCode != 0 {
+				assert.Equal(t, int64(tc.statusCode), attrs[keyHTTPStatusCode].AsInt64())
+			} else {
+				assert.NotContains(t, attrs, keyHTTPStatusCode)
+			}
+
+			assert.Equal(t, uint64(1), histogramCount(t, reader, apiDurationName))
+		})
+	}
+}
+
+func TestRPCCall(t *testing.T) {
+	instruments, recorder, reader := newTestInstruments(t)
+
+	_, call := instruments.StartRPC(context.Background(), "eth_call", 8453)
+	call.End(context.DeadlineExceeded)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "eth_call", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	attrs := spanAttributes(spans[0])
+	assert.Equal(t, "eth_call", attrs[keyRPCMethod].AsString())
+	assert.Equal(t, int64(8453), attrs[keyChainID].AsInt64())
+	assert.Equal(t, uint64(1), histogramCount(t, reader, rpcDurationName))
+}
+
+func TestNilInstruments(t *testing.T) {
+	var instruments *Instruments
+
+	ctx, request := instruments.StartAPIRequest(context.Background(), "GET", "/swap/v6.0/1/quote")
+	assert.NotNil(t, ctx)
+	request.End(200, 0, nil)
+
+	ctx, call := instruments.StartRPC(context.Background(), "eth_call", 1)
+	assert.NotNil(t, ctx)
+	call.End(nil)
+}
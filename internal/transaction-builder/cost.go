@@ -0,0 +1,170 @@
+package transaction_builder
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+	"github.com/1inch/1inch-sdk-go/v4/constants"
+)
+
+var (
+	opGasPriceOracleABI      = mustParseABI("GasPriceOracle", constants.OpGasPriceOracleABI)
+	arbitrumNodeInterfaceABI = mustParseABI("NodeInterface", constants.ArbitrumNodeInterfaceABI)
+)
+
+// mustParseABI parses one of the built-in contract ABIs, which are constants
+func mustParseABI(contract string, raw string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(raw))
+	if err != nil {
+		panic(fmt.Sprintf("invalid %s ABI: %v", contract, err))
+	}
+	return parsed
+}
+
+func (t *TransactionBuilder) EstimateCost(ctx context.Context, tx *types.Transaction) (*common.TransactionCost, error) {
+	switch t.wallet.ChainId() {
+	case constants.OptimismChainId, constants.BaseChainId:
+		return t.estimateOpStackCost(ctx, tx)
+	case constants.ArbitrumChainId:
+		return t.estimateArbitrumCost(ctx, tx)
+	}
+	gasPrice, err := t.expectedGasPrice(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	return newTransactionCost(tx.Gas(), gasPrice, new(big.Int)), nil
+}
+
+// estimateOpStackCost adds the L1 data fee that the GasPriceOracle predeploy charges for the
+// transaction's serialized form. The oracle assumes an unsigned transaction and accounts for
+// the signature itself.
+func (t *TransactionBuilder) estimateOpStackCost(ctx context.Context, tx *types.Transaction) (*common.TransactionCost, error) {
+	unsigned, err := unsignedBinary(tx)
+	if err != nil {
+		return nil, err
+	}
+	callData, err := opGasPriceOracleABI.Pack("getL1Fee", unsigned)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack getL1Fee: %w", err)
+	}
+	result, err := t.wallet.Call(ctx, gethCommon.HexToAddress(constants.OpGasPriceOracleAddress), callData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L1 fee: %w", err)
+	}
+	var l1Fee *big.Int
+	if err := opGasPriceOracleABI.UnpackIntoInterface(&l1Fee, "getL1Fee", result); err != nil {
+		return nil, fmt.Errorf("failed to unpack getL1Fee: %w", err)
+	}
+
+	gasPrice, err := t.expectedGasPrice(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	return newTransactionCost(tx.Gas(), gasPrice, l1Fee), nil
+}
+
+// estimateArbitrumCost gets the L1 part of the gas from the NodeInterface. Arbitrum charges
+// the L1 data fee as extra gas at the L2 base fee within the transaction's gas limit, so the
+// rest of the limit is the L2 gas. The priority fee is not paid, so the base fee is the
+// expected gas price.
+func (t *TransactionBuilder) estimateArbitrumCost(ctx context.Context, tx *types.Transaction) (*common.TransactionCost, error) {
+	var to gethCommon.Address
+	if tx.To() != nil {
+		to = *tx.To()
+	}
+	callData, err := arbitrumNodeInterfaceABI.Pack("gasEstimateComponents", to, tx.To() == nil, tx.Data())
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack gasEstimateComponents: %w", err)
+	}
+	nodeInterface := gethCommon.HexToAddress(constants.ArbitrumNodeInterfaceAddress)
+	result, err := t.wallet.Simulate(ctx, ethereum.CallMsg{
+		From:  t.wallet.Address(),
+		To:    &nodeInterface,
+		Value: tx.Value(),
+		Data:  callData,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas estimate components: %w", err)
+	}
+	var components struct {
+		GasEstimate       uint64
+		GasEstimateForL1  uint64
+		BaseFee           *big.Int
+		L1BaseFeeEstimate *big.Int
+	}
+	if err := arbitrumNodeInterfaceABI.UnpackIntoInterface(&components, "gasEstimateComponents", result); err != nil {
+		return nil, fmt.Errorf("failed to unpack gasEstimateComponents: %w", err)
+	}
+	if components.GasEstimateForL1 > tx.Gas() {
+		return nil, fmt.Errorf("invalid gas estimate components: L1 gas %d exceeds the gas limit %d",
+			components.GasEstimateForL1, tx.Gas())
+	}
+
+	// The fee cap of legacy transactions is their gas price
+	gasPrice := minBig(components.BaseFee, tx.GasFeeCap())
+	l1Fee := new(big.Int).Mul(new(big.Int).SetUint64(components.GasEstimateForL1), gasPrice)
+	return newTransactionCost(tx.Gas()-components.GasEstimateForL1, gasPrice, l1Fee), nil
+}
+
+// expectedGasPrice is the gas price of legacy transactions, and for dynamic-fee transactions
+// the node's suggested gas price capped by the fee cap
+func (t *TransactionBuilder) expectedGasPrice(ctx context.Context, tx *types.Transaction) (*big.Int, error) {
+	if tx.Type() == types.LegacyTxType || tx.Type() == types.AccessListTxType {
+		return tx.GasPrice(), nil
+	}
+	gasPrice, err := t.wallet.GetGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return minBig(gasPrice, tx.GasFeeCap()), nil
+}
+
+// unsignedBinary encodes tx without its signature
+func unsignedBinary(tx *types.Transaction) ([]byte, error) {
+	var inner types.TxData
+	switch tx.Type() {
+	case types.LegacyTxType:
+		inner = &types.LegacyTx{Nonce: tx.Nonce(), GasPrice: tx.GasPrice(), Gas: tx.Gas(), To: tx.To(), Value: tx.Value(), Data: tx.Data()}
+	case types.AccessListTxType:
+		inner = &types.AccessListTx{ChainID: tx.ChainId(), Nonce: tx.Nonce(), GasPrice: tx.GasPrice(), Gas: tx.Gas(),
+			To: tx.To(), Value: tx.Value(), Data: tx.Data(), AccessList: tx.AccessList()}
+	case types.DynamicFeeTxType:
+		inner = &types.DynamicFeeTx{ChainID: tx.ChainId(), Nonce: tx.Nonce(), GasTipCap: tx.GasTipCap(), GasFeeCap: tx.GasFeeCap(),
+			Gas: tx.Gas(), To: tx.To(), Value: tx.Value(), Data: tx.Data(), AccessList: tx.AccessList()}
+	case types.SetCodeTxType:
+		inner = &types.SetCodeTx{ChainID: uint256.MustFromBig(tx.ChainId()), Nonce: tx.Nonce(),
+			GasTipCap: uint256.MustFromBig(tx.GasTipCap()), GasFeeCap: uint256.MustFromBig(tx.GasFeeCap()), Gas: tx.Gas(),
+			To: *tx.To(), Value: uint256.MustFromBig(tx.Value()), Data: tx.Data(), AccessList: tx.AccessList(),
+			AuthList: tx.SetCodeAuthorizations()}
+	default:
+		return nil, fmt.Errorf("cannot estimate the L1 fee of transactions of type %d", tx.Type())
+	}
+	return types.NewTx(inner).MarshalBinary()
+}
+
+func newTransactionCost(l2Gas uint64, gasPrice *big.Int, l1Fee *big.Int) *common.TransactionCost {
+	l2Fee := new(big.Int).Mul(new(big.Int).SetUint64(l2Gas), gasPrice)
+	return &common.TransactionCost{
+		L2Gas:    l2Gas,
+		GasPrice: gasPrice,
+		L2Fee:    l2Fee,
+		L1Fee:    l1Fee,
+		Total:    new(big.Int).Add(l2Fee, l1Fee),
+	}
+}
+
+func minBig(a *big.Int, b *big.Int) *big.Int {
+	if a.Cmp(b) <= 0 {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,118 @@
+package transaction_builder
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+	"github.com/1inch/1inch-sdk-go/v4/constants"
+)
+
+func TestTransactionBuilder_EstimateCost(t *testing.T) {
+	to := gethCommon.HexToAddress("0x111111125421ca6dc452d289314280a0f8842a65")
+	dynamicTx := types.NewTx(&types.DynamicFeeTx{
+		ChainID: big.NewInt(1), Nonce: 1, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(30),
+		Gas: 200_000, To: &to, Value: big.NewInt(0), Data: []byte{0x01},
+	})
+	legacyTx := types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(40), Gas: 100_000, To: &to, Data: []byte{0x01}})
+
+	l1Fee := gethCommon.LeftPadBytes(big.NewInt(5_000_000).Bytes(), 32)
+	components, err := arbitrumNodeInterfaceABI.Methods["gasEstimateComponents"].Outputs.Pack(
+		uint64(250_000), uint64(50_000), big.NewInt(10), big.NewInt(20))
+	require.NoError(t, err)
+	badComponents, err := arbitrumNodeInterfaceABI.Methods["gasEstimateComponents"].Outputs.Pack(
+		uint64(50_000), uint64(250_000), big.NewInt(10), big.NewInt(20))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		chainId        int64
+		tx             *types.Transaction
+		callResult     []byte
+		simulateResult []byte
+		expected       common.TransactionCost
+		expectedCalled []gethCommon.Address
+		expectedError  string
+	}{
+		{
+			name:    "No data fee on Ethereum",
+			chainId: constants.EthereumChainId,
+			tx:      dynamicTx,
+			// The node suggests 23, below the fee cap
+			expected: common.TransactionCost{L2Gas: 200_000, GasPrice: big.NewInt(23), L2Fee: big.NewInt(4_600_000),
+				L1Fee: big.NewInt(0), Total: big.NewInt(4_600_000)},
+		},
+		{
+			name:    "Legacy transactions pay their gas price",
+			chainId: constants.BscChainId,
+			tx:      legacyTx,
+			expected: common.TransactionCost{L2Gas: 100_000, GasPrice: big.NewInt(40), L2Fee: big.NewInt(4_000_000),
+				L1Fee: big.NewInt(0), Total: big.NewInt(4_000_000)},
+		},
+		{
+			name:       "Gas price oracle on Base",
+			chainId:    constants.BaseChainId,
+			tx:         dynamicTx,
+			callResult: l1Fee,
+			expected: common.TransactionCost{L2Gas: 200_000, GasPrice: big.NewInt(23), L2Fee: big.NewInt(4_600_000),
+				L1Fee: big.NewInt(5_000_000), Total: big.NewInt(9_600_000)},
+			expectedCalled: []gethCommon.Address{gethCommon.HexToAddress(constants.OpGasPriceOracleAddress)},
+		},
+		{
+			name:           "Node interface on Arbitrum",
+			chainId:        constants.ArbitrumChainId,
+			tx:             dynamicTx,
+			simulateResult: components,
+			// The gas limit of 200000 less 50000 L1 gas, not the 250000 estimate
+			expected: common.TransactionCost{L2Gas: 150_000, GasPrice: big.NewInt(10), L2Fee: big.NewInt(1_500_000),
+				L1Fee: big.NewInt(500_000), Total: big.NewInt(2_000_000)},
+		},
+		{
+			name:           "Invalid node interface answer",
+			chainId:        constants.ArbitrumChainId,
+			tx:             dynamicTx,
+			simulateResult: badComponents,
+			expectedError:  "invalid gas estimate components: L1 gas 250000 exceeds the gas limit 200000",
+		},
+		{
+			name:          "Empty oracle answer",
+			chainId:       constants.OptimismChainId,
+			tx:            dynamicTx,
+			expectedError: "failed to unpack getL1Fee: abi: attempting to unmarshal an empty string while arguments are expected",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := NewMyWallet(gethCommon.HexToAddress("0x0000000000000000000000000000000000000001"), big.NewInt(tc.chainId))
+			w.callResult = tc.callResult
+			w.simulateResult = tc.simulateResult
+
+			cost, err := NewFactory(w).New().EstimateCost(context.Background(), tc.tx)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected.L2Gas, cost.L2Gas)
+			for name, pair := range map[string][2]*big.Int{
+				"GasPrice": {tc.expected.GasPrice, cost.GasPrice},
+				"L2Fee":    {tc.expected.L2Fee, cost.L2Fee},
+				"L1Fee":    {tc.expected.L1Fee, cost.L1Fee},
+				"Total":    {tc.expected.Total, cost.Total},
+			} {
+				require.Zero(t, pair[0].Cmp(pair[1]), "%s: expected %s, got %s", name, pair[0], pair[1])
+			}
+			require.Equal(t, tc.expectedCalled, w.called)
+			if tc.chainId == constants.ArbitrumChainId {
+				require.Len(t, w.simulated, 1)
+				require.Equal(t, gethCommon.HexToAddress(constants.ArbitrumNodeInterfaceAddress), *w.simulated[0].To)
+			}
+		})
+	}
+}
@@ -3,17 +3,23 @@ package transaction_builder
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"math/big"
+	"slices"
 
 	"github.com/ethereum/go-ethereum"
 	gethCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
 
 	"github.com/1inch/1inch-sdk-go/v4/common"
 )
 
 type TransactionBuilder struct {
 	wallet    common.Wallet
+	logger    *slog.Logger
+	fees      common.FeeStrategy
+	nonces    common.NonceManager
 	nonce     *uint64
 	gasPrice  *big.Int
 	gas       *uint64
@@ -22,6 +28,15 @@ type TransactionBuilder struct {
 	data      []byte
 	gasTipCap *big.Int
 	gasFeeCap *big.Int
+
+	accessList         types.AccessList
+	generateAccessList bool
+
+	simulate bool
+
+	authorizations []types.SetCodeAuthorization
+	// delegate is the contract the wallet's own account delegates to, signed at build time
+	delegate *gethCommon.Address
 }
 
 func (t *TransactionBuilder) SetData(d []byte) common.TransactionBuilder {
@@ -82,35 +97,84 @@ func (t *TransactionBuilder) SetGasFeeCap(g *big.Int) common.TransactionBuilder
 	return t
 }
 
+func (t *TransactionBuilder) SetAccessList(accessList types.AccessList) common.TransactionBuilder {
+	if accessList == nil {
+		return t
+	}
+	t.accessList = accessList
+	return t
+}
+
+func (t *TransactionBuilder) GenerateAccessList() common.TransactionBuilder {
+	t.generateAccessList = true
+	return t
+}
+
+func (t *TransactionBuilder) SetAuthorizationList(authorizations []types.SetCodeAuthorization) common.TransactionBuilder {
+	// Copied, because the build appends the wallet's own authorization to the list
+	t.authorizations = slices.Clone(authorizations)
+	return t
+}
+
+func (t *TransactionBuilder) Delegate(contract gethCommon.Address) common.TransactionBuilder {
+	t.delegate = &contract
+	return t
+}
+
+func (t *TransactionBuilder) Simulate() common.TransactionBuilder {
+	t.simulate = true
+	return t
+}
+
 func (t *TransactionBuilder) BuildLegacyTx(ctx context.Context) (*types.Transaction, error) {
 	if t.to == nil && t.data == nil {
 		return nil, fmt.Errorf("transaction requires data or to address")
 	}
+	if t.hasAccessList() {
+		return nil, fmt.Errorf("legacy transactions cannot carry an access list: use BuildAccessListTx")
+	}
+	if t.hasAuthorizations() {
+		return nil, fmt.Errorf("only set-code transactions can carry authorizations: use BuildSetCodeTx")
+	}
 
-	if t.nonce == nil {
-		nonce, err := t.wallet.Nonce(ctx)
+	if t.gasPrice == nil {
+		gasPrice, err := t.fees.GasPrice(ctx, t.wallet)
 		if err != nil {
 			return nil, err
 		}
-		t.nonce = &nonce
+		t.gasPrice = gasPrice
 	}
 
-	if t.gasPrice == nil {
-		gasPrice, err := t.wallet.GetGasPrice(ctx)
+	if err := t.createAccessList(ctx); err != nil {
+		return nil, err
+	}
+	if err := t.estimateGas(ctx); err != nil {
+		return nil, err
+	}
+	if err := t.simulateTx(ctx); err != nil {
+		return nil, err
+	}
+
+	// The nonce is taken last: with a nonce manager it is reserved, and a failure
+	// in any earlier step would leave it unused
+	if t.nonce == nil {
+		nonce, err := t.wallet.Nonce(ctx)
 		if err != nil {
 			return nil, err
 		}
-		t.gasPrice = gasPrice
+		t.nonce = &nonce
 	}
 
-	return types.NewTx(&types.LegacyTx{
+	tx := types.NewTx(&types.LegacyTx{
 		Nonce:    *t.nonce,
 		GasPrice: t.gasPrice,
 		Gas:      *t.gas,
 		To:       t.to,
 		Value:    t.value,
 		Data:     t.data,
-	}), nil
+	})
+	t.logBuilt(ctx, tx)
+	return tx, nil
 }
 
 func (t *TransactionBuilder) BuildDynamicTx(ctx context.Context) (*types.Transaction, error) {
@@ -121,6 +185,23 @@ func (t *TransactionBuilder) BuildDynamicTx(ctx context.Context) (*types.Transac
 	if t.to == nil && t.data == nil {
 		return nil, fmt.Errorf("transaction requires data or to address")
 	}
+	if t.hasAuthorizations() {
+		return nil, fmt.Errorf("only set-code transactions can carry authorizations: use BuildSetCodeTx")
+	}
+
+	if err := t.dynamicFees(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := t.createAccessList(ctx); err != nil {
+		return nil, err
+	}
+	if err := t.estimateGas(ctx); err != nil {
+		return nil, err
+	}
+	if err := t.simulateTx(ctx); err != nil {
+		return nil, err
+	}
 
 	if t.nonce == nil {
 		nonce, err := t.wallet.Nonce(ctx)
@@ -130,61 +211,289 @@ func (t *TransactionBuilder) BuildDynamicTx(ctx context.Context) (*types.Transac
 		t.nonce = &nonce
 	}
 
-	if t.gasTipCap == nil {
-		gasTipCap, err := t.wallet.GetGasTipCap(ctx)
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:    big.NewInt(t.wallet.ChainId()),
+		Nonce:      *t.nonce,
+		GasTipCap:  t.gasTipCap,
+		GasFeeCap:  t.gasFeeCap,
+		Gas:        *t.gas,
+		To:         t.to,
+		Value:      t.value,
+		Data:       t.data,
+		AccessList: t.accessList,
+	})
+	t.logBuilt(ctx, tx)
+	return tx, nil
+}
+
+func (t *TransactionBuilder) BuildSetCodeTx(ctx context.Context) (_ *types.Transaction, err error) {
+	if !t.wallet.IsEIP1559Applicable() {
+		return nil, fmt.Errorf("unsupported: set-code transactions on this chain")
+	}
+	if t.to == nil {
+		return nil, fmt.Errorf("set-code transactions require a to address")
+	}
+	if !t.hasAuthorizations() {
+		return nil, fmt.Errorf("set-code transactions require an authorization list")
+	}
+	value := new(uint256.Int)
+	if t.value != nil {
+		if overflow := value.SetFromBig(t.value); overflow {
+			return nil, fmt.Errorf("value %s does not fit in 256 bits", t.value)
+		}
+	}
+
+	if err := t.dynamicFees(ctx); err != nil {
+		return nil, err
+	}
+
+	// The wallet's own authorization must carry the nonce after the transaction's, because the
+	// sender's nonce is incremented before the authorizations are processed. The nonce is
+	// therefore taken before the gas estimate, which needs the signed authorization.
+	if t.delegate != nil {
+		if t.nonce == nil {
+			var nonce uint64
+			if nonce, err = t.wallet.Nonce(ctx); err != nil {
+				return nil, err
+			}
+			t.nonce = &nonce
+			// A failure in the steps below would otherwise leave the reserved nonce unused
+			defer t.releaseDelegation(&err, t.delegate, len(t.authorizations))
+		}
+		auth, err := t.wallet.SignAuthorization(ctx, types.SetCodeAuthorization{
+			ChainID: *uint256.NewInt(uint64(t.wallet.ChainId())),
+			Address: *t.delegate,
+			Nonce:   *t.nonce + 1,
+		})
 		if err != nil {
 			return nil, err
 		}
-		t.gasTipCap = gasTipCap
+		t.authorizations = append(t.authorizations, auth)
+		t.delegate = nil
 	}
 
-	if t.gasFeeCap == nil {
-		gasPrice, err := t.wallet.GetGasPrice(ctx)
+	if err := t.createAccessList(ctx); err != nil {
+		return nil, err
+	}
+	if err := t.estimateGas(ctx); err != nil {
+		return nil, err
+	}
+	if err := t.simulateTx(ctx); err != nil {
+		return nil, err
+	}
+
+	if t.nonce == nil {
+		nonce, err := t.wallet.Nonce(ctx)
 		if err != nil {
 			return nil, err
 		}
-		// The node's suggested gas price tracks the current base fee, so using it
-		// directly as the fee cap makes the transaction invalid as soon as the base
-		// fee rises before inclusion. Doubling the suggestion keeps the cap at or
-		// above twice the base fee plus the tip; the network only charges base fee
-		// plus tip, so the headroom adds no cost.
-		t.gasFeeCap = new(big.Int).Mul(gasPrice, big.NewInt(2))
-		if t.gasFeeCap.Cmp(t.gasTipCap) < 0 {
-			t.gasFeeCap = new(big.Int).Set(t.gasTipCap)
+		t.nonce = &nonce
+	}
+
+	tx := types.NewTx(&types.SetCodeTx{
+		ChainID:    uint256.NewInt(uint64(t.wallet.ChainId())),
+		Nonce:      *t.nonce,
+		GasTipCap:  uint256.MustFromBig(t.gasTipCap),
+		GasFeeCap:  uint256.MustFromBig(t.gasFeeCap),
+		Gas:        *t.gas,
+		To:         *t.to,
+		Value:      value,
+		Data:       t.data,
+		AccessList: t.accessList,
+		AuthList:   t.authorizations,
+	})
+	t.logBuilt(ctx, tx)
+	return tx, nil
+}
+
+func (t *TransactionBuilder) BuildAccessListTx(ctx context.Context) (*types.Transaction, error) {
+	if t.to == nil && t.data == nil {
+		return nil, fmt.Errorf("transaction requires data or to address")
+	}
+	if t.hasAuthorizations() {
+		return nil, fmt.Errorf("only set-code transactions can carry authorizations: use BuildSetCodeTx")
+	}
+
+	if t.gasPrice == nil {
+		gasPrice, err := t.fees.GasPrice(ctx, t.wallet)
+		if err != nil {
+			return nil, err
 		}
+		t.gasPrice = gasPrice
 	}
 
-	if t.gas == nil {
-		// Fee fields are omitted from the estimate: gas usage does not depend on
-		// them, and including a price makes the node reject the estimate whenever
-		// the base fee moves or the account cannot prepay at the capped price
-		gas, err := t.wallet.GetGasEstimate(ctx, ethereum.CallMsg{
-			From:  t.wallet.Address(),
-			To:    t.to,
-			Value: t.value,
-			Data:  t.data,
-		})
+	if err := t.createAccessList(ctx); err != nil {
+		return nil, err
+	}
+	if err := t.estimateGas(ctx); err != nil {
+		return nil, err
+	}
+	if err := t.simulateTx(ctx); err != nil {
+		return nil, err
+	}
+
+	if t.nonce == nil {
+		nonce, err := t.wallet.Nonce(ctx)
 		if err != nil {
 			return nil, err
 		}
-		t.gas = &gas
+		t.nonce = &nonce
 	}
 
-	return types.NewTx(&types.DynamicFeeTx{
-		ChainID:   big.NewInt(t.wallet.ChainId()),
-		Nonce:     *t.nonce,
-		GasTipCap: t.gasTipCap,
-		GasFeeCap: t.gasFeeCap,
-		Gas:       *t.gas,
-		To:        t.to,
-		Value:     t.value,
-		Data:      t.data,
-	}), nil
+	tx := types.NewTx(&types.AccessListTx{
+		ChainID:    big.NewInt(t.wallet.ChainId()),
+		Nonce:      *t.nonce,
+		GasPrice:   t.gasPrice,
+		Gas:        *t.gas,
+		To:         t.to,
+		Value:      t.value,
+		Data:       t.data,
+		AccessList: t.accessList,
+	})
+	t.logBuilt(ctx, tx)
+	return tx, nil
 }
 
 func (t *TransactionBuilder) Build(ctx context.Context) (*types.Transaction, error) {
+	if t.hasAuthorizations() {
+		return t.BuildSetCodeTx(ctx)
+	}
 	if t.wallet.IsEIP1559Applicable() {
 		return t.BuildDynamicTx(ctx)
 	}
+	if t.hasAccessList() {
+		return t.BuildAccessListTx(ctx)
+	}
 	return t.BuildLegacyTx(ctx)
 }
+
+// releaseDelegation undoes the nonce reservation and the signed delegation of a set-code build
+// that failed with *err, returning the nonce to the nonce manager so the next build reuses it
+func (t *TransactionBuilder) releaseDelegation(err *error, delegate *gethCommon.Address, authorizations int) {
+	if *err == nil {
+		return
+	}
+	if t.nonces != nil {
+		t.nonces.Release(*t.nonce)
+	}
+	t.nonce = nil
+	t.delegate = delegate
+	t.authorizations = t.authorizations[:authorizations]
+}
+
+func (t *TransactionBuilder) hasAuthorizations() bool {
+	return len(t.authorizations) > 0 || t.delegate != nil
+}
+
+// dynamicFees fills in the fees of EIP-1559 transactions that are not set
+func (t *TransactionBuilder) dynamicFees(ctx context.Context) error {
+	if t.gasTipCap != nil && t.gasFeeCap != nil {
+		return nil
+	}
+	gasTipCap, gasFeeCap, err := t.fees.DynamicFees(ctx, t.wallet)
+	if err != nil {
+		return err
+	}
+	if t.gasTipCap == nil {
+		t.gasTipCap = gasTipCap
+	}
+	if t.gasFeeCap == nil {
+		// A fee cap below the tip is invalid, which happens when only the tip is set explicitly
+		t.gasFeeCap = maxBig(gasFeeCap, t.gasTipCap)
+	}
+	if ceiling, ok := t.fees.(common.FeeCeiling); ok {
+		return ceiling.CheckDynamicFees(t.gasTipCap, t.gasFeeCap)
+	}
+	return nil
+}
+
+func (t *TransactionBuilder) hasAccessList() bool {
+	return t.accessList != nil || t.generateAccessList
+}
+
+// createAccessList asks the node for the access list when generation is requested and none is set
+func (t *TransactionBuilder) createAccessList(ctx context.Context) error {
+	if !t.generateAccessList || t.accessList != nil {
+		return nil
+	}
+	accessList, err := t.wallet.CreateAccessList(ctx, ethereum.CallMsg{
+		From:              t.wallet.Address(),
+		To:                t.to,
+		Value:             t.value,
+		Data:              t.data,
+		AuthorizationList: t.authorizations,
+	})
+	if err != nil {
+		return err
+	}
+	t.accessList = accessList
+	return nil
+}
+
+// estimateGas asks the node for the gas limit unless one is set
+func (t *TransactionBuilder) estimateGas(ctx context.Context) error {
+	if t.gas != nil {
+		return nil
+	}
+	// Fee fields are omitted from the estimate: gas usage does not depend on
+	// them, and including a price makes the node reject the estimate whenever
+	// the base fee moves or the account cannot prepay at the capped price
+	gas, err := t.wallet.GetGasEstimate(ctx, ethereum.CallMsg{
+		From:              t.wallet.Address(),
+		To:                t.to,
+		Value:             t.value,
+		Data:              t.data,
+		AccessList:        t.accessList,
+		AuthorizationList: t.authorizations,
+	})
+	if err != nil {
+		return err
+	}
+	t.gas = &gas
+	return nil
+}
+
+// simulateTx executes the transaction against the pending block when simulation is requested, so
+// a revert surfaces as a decoded error before the nonce is taken and the transaction is signed
+func (t *TransactionBuilder) simulateTx(ctx context.Context) error {
+	if !t.simulate {
+		return nil
+	}
+	// Fee fields are omitted for the same reason as in estimateGas
+	_, err := t.wallet.Simulate(ctx, ethereum.CallMsg{
+		From:              t.wallet.Address(),
+		To:                t.to,
+		Gas:               *t.gas,
+		Value:             t.value,
+		Data:              t.data,
+		AccessList:        t.accessList,
+		AuthorizationList: t.authorizations,
+	})
+	return err
+}
+
+func maxBig(a *big.Int, b *big.Int) *big.Int {
+	if a.Cmp(b) >= 0 {
+		return a
+	}
+	return new(big.Int).Set(b)
+}
+
+// logBuilt logs the fields the builder filled in, which is where fee and nonce problems show up.
+func (t *TransactionBuilder) logBuilt(ctx context.Context, tx *types.Transaction) {
+	if t.logger == nil || !t.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	to := ""
+	if tx.To() != nil {
+		to = tx.To().Hex()
+	}
+	t.logger.DebugContext(ctx, "transaction built",
+		slog.Int("type", int(tx.Type())),
+		slog.Uint64("nonce", tx.Nonce()),
+		slog.Uint64("gas", tx.Gas()),
+		slog.String("gasPrice", tx.GasPrice().String()),
+		slog.String("gasTipCap", tx.GasTipCap().String()),
+		slog.String("gasFeeCap", tx.GasFeeCap().String()),
+		slog.String("to", to))
+}
@@ -1,22 +1,40 @@
 package transaction_builder
 
 import (
+	"log/slog"
+
 	"github.com/1inch/1inch-sdk-go/v4/common"
+	"github.com/1inch/1inch-sdk-go/v4/common/fees"
+	"github.com/1inch/1inch-sdk-go/v4/internal/logging"
 )
 
 type TransactionBuilderFactory struct {
 	wallet common.Wallet
+	logger *slog.Logger
+	fees   common.FeeStrategy
+	nonces common.NonceManager
 }
 
-func NewFactory(w common.Wallet) TransactionBuilderFactory {
+func NewFactory(w common.Wallet, opts ...common.WalletOption) TransactionBuilderFactory {
+	cfg := common.NewWalletConfig(opts...)
+	feeStrategy := cfg.FeeStrategy
+	if feeStrategy == nil {
+		feeStrategy = fees.Node()
+	}
 	return TransactionBuilderFactory{
 		wallet: w,
+		logger: logging.New(cfg.Logger),
+		fees:   feeStrategy,
+		nonces: cfg.NonceManager,
 	}
 }
 
 func (f TransactionBuilderFactory) New() common.TransactionBuilder {
 	return &TransactionBuilder{
 		wallet:    f.wallet,
+		logger:    f.logger,
+		fees:      f.fees,
+		nonces:    f.nonces,
 		nonce:     nil,
 		gasPrice:  nil,
 		gas:       nil,
@@ -9,9 +9,14 @@ import (
 	"github.com/ethereum/go-ethereum"
 	gethCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/require"
 
 	"github.com/1inch/1inch-sdk-go/v4/common"
+	"github.com/1inch/1inch-sdk-go/v4/common/fees"
+	"github.com/1inch/1inch-sdk-go/v4/common/nonce"
+	"github.com/1inch/1inch-sdk-go/v4/common/revert"
 )
 
 func TestTransactionBuilder_Build(t *testing.T) {
@@ -192,9 +197,395 @@ func TestTransactionBuilder_Build(t *testing.T) {
 	}
 }
 
+func TestTransactionBuilder_BuildLegacyTx(t *testing.T) {
+	w := NewMyWallet(gethCommon.HexToAddress("0x0000000000000000000000000000000000000000"), big.NewInt(1))
+	to := gethCommon.HexToAddress("0x000000000000000000000000000000000000dead")
+
+	tx, err := NewFactory(w).New().SetTo(&to).SetData([]byte{0x01}).BuildLegacyTx(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(123), tx.Gas(), "the gas limit is estimated when not set")
+	require.Equal(t, big.NewInt(23), tx.GasPrice())
+	require.Equal(t, uint64(44), tx.Nonce())
+}
+
+func TestTransactionBuilder_AccessList(t *testing.T) {
+	to := gethCommon.HexToAddress("0x000000000000000000000000000000000000dead")
+	generated := types.AccessList{{
+		Address:     gethCommon.HexToAddress("0x00000000000000000000000000000000000000aa"),
+		StorageKeys: []gethCommon.Hash{gethCommon.HexToHash("0x01")},
+	}}
+	explicit := types.AccessList{{Address: gethCommon.HexToAddress("0x00000000000000000000000000000000000000bb")}}
+
+	tests := []struct {
+		name               string
+		legacy             bool
+		accessList         types.AccessList
+		generate           bool
+		build              func(common.TransactionBuilder, context.Context) (*types.Transaction, error)
+		expectedType       uint8
+		expectedAccessList types.AccessList
+		expectedGas        uint64
+		expectedError      string
+	}{
+		{
+			name:               "Generated for a dynamic transaction",
+			generate:           true,
+			build:              common.TransactionBuilder.Build,
+			expectedType:       types.DynamicFeeTxType,
+			expectedAccessList: generated,
+			expectedGas:        1123,
+		},
+		{
+			name:               "Explicit list is not regenerated",
+			accessList:         explicit,
+			generate:           true,
+			build:              common.TransactionBuilder.BuildDynamicTx,
+			expectedType:       types.DynamicFeeTxType,
+			expectedAccessList: explicit,
+			expectedGas:        1123,
+		},
+		{
+			name:               "Build uses an access list transaction on legacy chains",
+			legacy:             true,
+			generate:           true,
+			build:              common.TransactionBuilder.Build,
+			expectedType:       types.AccessListTxType,
+			expectedAccessList: generated,
+			expectedGas:        1123,
+		},
+		{
+			name:         "Build without an access list on legacy chains",
+			legacy:       true,
+			build:        common.TransactionBuilder.Build,
+			expectedType: types.LegacyTxType,
+			expectedGas:  123,
+		},
+		{
+			name:          "Legacy transaction cannot carry an access list",
+			accessList:    explicit,
+			build:         common.TransactionBuilder.BuildLegacyTx,
+			expectedError: "legacy transactions cannot carry an access list: use BuildAccessListTx",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := NewMyWallet(gethCommon.HexToAddress("0x0000000000000000000000000000000000000000"), big.NewInt(1))
+			w.legacy = tc.legacy
+			w.accessList = generated
+			builder := NewFactory(w).New().SetTo(&to).SetData([]byte{0x01}).SetAccessList(tc.accessList)
+			if tc.generate {
+				builder = builder.GenerateAccessList()
+			}
+
+			tx, err := tc.build(builder, context.Background())
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedType, tx.Type())
+			require.Equal(t, tc.expectedAccessList, tx.AccessList())
+			require.Equal(t, tc.expectedGas, tx.Gas(), "gas is estimated with the access list")
+			if tc.expectedType == types.AccessListTxType {
+				require.Equal(t, big.NewInt(23), tx.GasPrice())
+			}
+		})
+	}
+}
+
+func TestTransactionBuilder_Simulate(t *testing.T) {
+	to := gethCommon.HexToAddress("0x000000000000000000000000000000000000dead")
+	reverted := revert.Decode(gethCommon.FromHex("0x5cd5d233"))
+
+	tests := []struct {
+		name              string
+		simulate          bool
+		simulateErr       error
+		expectedSimulated int
+		expectedErrorIs   error
+	}{
+		{
+			name: "Not requested",
+		},
+		{
+			name:              "Succeeds",
+			simulate:          true,
+			expectedSimulated: 1,
+		},
+		{
+			name:              "Reverts",
+			simulate:          true,
+			simulateErr:       fmt.Errorf("simulation failed: %w", reverted),
+			expectedSimulated: 1,
+			expectedErrorIs:   revert.ErrBadSignature,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := NewMyWallet(gethCommon.HexToAddress("0x0000000000000000000000000000000000000001"), big.NewInt(1))
+			w.simulateErr = tc.simulateErr
+			builder := NewFactory(w).New().SetTo(&to).SetData([]byte{0x01}).SetValue(big.NewInt(5))
+			if tc.simulate {
+				builder = builder.Simulate()
+			}
+
+			tx, err := builder.Build(context.Background())
+			require.Len(t, w.simulated, tc.expectedSimulated)
+			if tc.expectedErrorIs != nil {
+				require.ErrorIs(t, err, tc.expectedErrorIs)
+				require.Nil(t, tx)
+				return
+			}
+			require.NoError(t, err)
+			if tc.simulate {
+				require.Equal(t, ethereum.CallMsg{
+					From:  w.address,
+					To:    &to,
+					Gas:   tx.Gas(),
+					Value: big.NewInt(5),
+					Data:  []byte{0x01},
+				}, w.simulated[0])
+			}
+		})
+	}
+}
+
+func TestTransactionBuilder_SetCode(t *testing.T) {
+	to := gethCommon.HexToAddress("0x0000000000000000000000000000000000000001")
+	delegate := gethCommon.HexToAddress("0x63c0c19a282a1b52b07dd5a65b58948a07dae32b")
+	sponsored := types.SetCodeAuthorization{ChainID: *uint256.NewInt(1), Address: delegate, Nonce: 3}
+
+	tests := []struct {
+		name                   string
+		legacy                 bool
+		to                     *gethCommon.Address
+		previous               []types.SetCodeAuthorization
+		authorizations         []types.SetCodeAuthorization
+		delegate               bool
+		build                  func(common.TransactionBuilder, context.Context) (*types.Transaction, error)
+		expectedAuthorizations []types.SetCodeAuthorization
+		expectedError          string
+	}{
+		{
+			name:     "Wallet delegates its own account",
+			to:       &to,
+			delegate: true,
+			build:    common.TransactionBuilder.Build,
+			expectedAuthorizations: []types.SetCodeAuthorization{
+				{ChainID: *uint256.NewInt(1), Address: delegate, Nonce: 45},
+			},
+		},
+		{
+			name:                   "Signed authorizations",
+			to:                     &to,
+			authorizations:         []types.SetCodeAuthorization{sponsored},
+			build:                  common.TransactionBuilder.BuildSetCodeTx,
+			expectedAuthorizations: []types.SetCodeAuthorization{sponsored},
+		},
+		{
+			name:                   "Setting the list again replaces it",
+			to:                     &to,
+			previous:               []types.SetCodeAuthorization{{ChainID: *uint256.NewInt(1), Address: to, Nonce: 9}},
+			authorizations:         []types.SetCodeAuthorization{sponsored},
+			build:                  common.TransactionBuilder.BuildSetCodeTx,
+			expectedAuthorizations: []types.SetCodeAuthorization{sponsored},
+		},
+		{
+			name:          "No authorizations",
+			to:            &to,
+			build:         common.TransactionBuilder.BuildSetCodeTx,
+			expectedError: "set-code transactions require an authorization list",
+		},
+		{
+			name:          "No to address",
+			delegate:      true,
+			build:         common.TransactionBuilder.Build,
+			expectedError: "set-code transactions require a to address",
+		},
+		{
+			name:          "Chain without EIP-1559",
+			legacy:        true,
+			to:            &to,
+			delegate:      true,
+			build:         common.TransactionBuilder.Build,
+			expectedError: "unsupported: set-code transactions on this chain",
+		},
+		{
+			name:           "Dynamic transaction cannot carry authorizations",
+			to:             &to,
+			authorizations: []types.SetCodeAuthorization{sponsored},
+			build:          common.TransactionBuilder.BuildDynamicTx,
+			expectedError:  "only set-code transactions can carry authorizations: use BuildSetCodeTx",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := NewMyWallet(to, big.NewInt(1))
+			w.legacy = tc.legacy
+			builder := NewFactory(w).New().SetTo(tc.to).SetData([]byte{0x01})
+			if tc.previous != nil {
+				builder = builder.SetAuthorizationList(tc.previous)
+			}
+			builder = builder.SetAuthorizationList(tc.authorizations)
+			if tc.delegate {
+				builder = builder.Delegate(delegate)
+			}
+
+			tx, err := tc.build(builder, context.Background())
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, uint8(types.SetCodeTxType), tx.Type())
+			require.Equal(t, uint64(44), tx.Nonce())
+			require.Equal(t, to, *tx.To())
+			require.Equal(t, uint64(123), tx.Gas())
+			require.Equal(t, tc.expectedAuthorizations, tx.SetCodeAuthorizations())
+		})
+	}
+}
+
+func TestTransactionBuilder_SetCodeReleasesNonce(t *testing.T) {
+	to := gethCommon.HexToAddress("0x000000000000000000000000000000000000dead")
+	delegate := gethCommon.HexToAddress("0x63c0c19a282a1b52b07dd5a65b58948a07dae32b")
+	manager := nonce.NewManager()
+	w := NewMyWallet(to, big.NewInt(1))
+	w.nonces = manager
+	w.simulateErr = fmt.Errorf("execution reverted")
+
+	factory := NewFactory(w, common.WithNonceManager(manager))
+	builder := factory.New().SetTo(&to).SetData([]byte{0x01}).Delegate(delegate).Simulate()
+	_, err := builder.BuildSetCodeTx(context.Background())
+	require.ErrorContains(t, err, "execution reverted")
+
+	// The failed build released nonce 44 and dropped its authorization, so a new builder and
+	// the failed one both take nonce 44 again and sign the delegation with nonce 45
+	w.simulateErr = nil
+	for _, builder := range []common.TransactionBuilder{factory.New().SetTo(&to).SetData([]byte{0x01}).Delegate(delegate), builder} {
+		tx, err := builder.BuildSetCodeTx(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, uint64(44), tx.Nonce())
+		require.Equal(t, []types.SetCodeAuthorization{
+			{ChainID: *uint256.NewInt(1), Address: delegate, Nonce: 45},
+		}, tx.SetCodeAuthorizations())
+		manager.Release(tx.Nonce())
+	}
+}
+
+type fixedFeeStrategy struct {
+	gasTipCap *big.Int
+	gasFeeCap *big.Int
+	gasPrice  *big.Int
+	err       error
+}
+
+func (s fixedFeeStrategy) DynamicFees(context.Context, common.Wallet) (*big.Int, *big.Int, error) {
+	return s.gasTipCap, s.gasFeeCap, s.err
+}
+
+func (s fixedFeeStrategy) GasPrice(context.Context, common.Wallet) (*big.Int, error) {
+	return s.gasPrice, s.err
+}
+
+func TestTransactionBuilder_FeeStrategy(t *testing.T) {
+	w := NewMyWallet(gethCommon.HexToAddress("0x0000000000000000000000000000000000000000"), big.NewInt(1))
+	to := gethCommon.HexToAddress("0x000000000000000000000000000000000000dead")
+
+	tests := []struct {
+		name              string
+		strategy          common.FeeStrategy
+		gasTipCap         *big.Int
+		legacy            bool
+		expectedGasTipCap *big.Int
+		expectedGasFeeCap *big.Int
+		expectedGasPrice  *big.Int
+		expectedError     string
+	}{
+		{
+			name:              "Dynamic fees from the strategy",
+			strategy:          fixedFeeStrategy{gasTipCap: big.NewInt(2), gasFeeCap: big.NewInt(70)},
+			expectedGasTipCap: big.NewInt(2),
+			expectedGasFeeCap: big.NewInt(70),
+		},
+		{
+			name:              "Explicit tip takes precedence",
+			strategy:          fixedFeeStrategy{gasTipCap: big.NewInt(2), gasFeeCap: big.NewInt(70)},
+			gasTipCap:         big.NewInt(90),
+			expectedGasTipCap: big.NewInt(90),
+			expectedGasFeeCap: big.NewInt(90),
+		},
+		{
+			name:              "Explicit tip within the ceiling",
+			strategy:          fees.MaxFee(fixedFeeStrategy{gasTipCap: big.NewInt(2), gasFeeCap: big.NewInt(70)}, big.NewInt(80)),
+			gasTipCap:         big.NewInt(75),
+			expectedGasTipCap: big.NewInt(75),
+			expectedGasFeeCap: big.NewInt(75),
+		},
+		{
+			name:          "Explicit tip above the ceiling",
+			strategy:      fees.MaxFee(fixedFeeStrategy{gasTipCap: big.NewInt(2), gasFeeCap: big.NewInt(70)}, big.NewInt(80)),
+			gasTipCap:     big.NewInt(90),
+			expectedError: "fee exceeds the configured maximum: gas tip cap 90 > 80",
+		},
+		{
+			name:             "Legacy gas price from the strategy",
+			strategy:         fixedFeeStrategy{gasPrice: big.NewInt(31)},
+			legacy:           true,
+			expectedGasPrice: big.NewInt(31),
+		},
+		{
+			name:          "Strategy error",
+			strategy:      fixedFeeStrategy{err: fmt.Errorf("fee exceeds the configured maximum")},
+			expectedError: "fee exceeds the configured maximum",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := NewFactory(w, common.WithFeeStrategy(tc.strategy)).New().SetTo(&to).SetGasTipCap(tc.gasTipCap)
+			var tx *types.Transaction
+			var err error
+			if tc.legacy {
+				tx, err = builder.BuildLegacyTx(context.Background())
+			} else {
+				tx, err = builder.BuildDynamicTx(context.Background())
+			}
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			if tc.legacy {
+				require.Equal(t, tc.expectedGasPrice, tx.GasPrice())
+				return
+			}
+			require.Equal(t, tc.expectedGasTipCap, tx.GasTipCap())
+			require.Equal(t, tc.expectedGasFeeCap, tx.GasFeeCap())
+		})
+	}
+}
+
 type MyWallet struct {
 	address gethCommon.Address
 	chainID *big.Int
+	// legacy makes the wallet report a chain without EIP-1559
+	legacy bool
+	// accessList is returned by CreateAccessList
+	accessList types.AccessList
+	// simulated records the messages passed to Simulate, which returns simulateResult or
+	// fails with simulateErr
+	simulated      []ethereum.CallMsg
+	simulateResult []byte
+	simulateErr    error
+	// called records the contracts passed to Call, which returns callResult
+	called     []gethCommon.Address
+	callResult []byte
+	// nonces makes Nonce reserve nonces from a manager, starting at 44
+	nonces common.NonceManager
 }
 
 func (w *MyWallet) GetContractDetailsForPermit(ctx context.Context, token gethCommon.Address, spender gethCommon.Address, amount *big.Int, deadline int64) (*common.ContractPermitData, error) {
@@ -209,10 +600,14 @@ func NewMyWallet(address gethCommon.Address, chainID *big.Int) *MyWallet {
 }
 
 func (w *MyWallet) Call(ctx context.Context, contractAddress gethCommon.Address, callData []byte) ([]byte, error) {
-	return nil, nil
+	w.called = append(w.called, contractAddress)
+	return w.callResult, nil
 }
 
 func (w *MyWallet) Nonce(ctx context.Context) (uint64, error) {
+	if w.nonces != nil {
+		return w.nonces.Next(ctx, func(context.Context) (uint64, error) { return 44, nil })
+	}
 	return 44, nil
 }
 
@@ -234,7 +629,7 @@ func (w *MyWallet) GetGasPrice(ctx context.Context) (*big.Int, error) {
 }
 
 func (w MyWallet) GetGasEstimate(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
-	return 123, nil
+	return 123 + 1000*uint64(len(msg.AccessList)), nil
 }
 
 func (w *MyWallet) Sign(tx *types.Transaction) (*types.Transaction, error) {
@@ -245,10 +640,22 @@ func (w *MyWallet) SignBytes(data []byte) ([]byte, error) {
 	return nil, nil
 }
 
+func (w *MyWallet) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	return nil, nil
+}
+
+func (w *MyWallet) SignAuthorization(ctx context.Context, auth types.SetCodeAuthorization) (types.SetCodeAuthorization, error) {
+	return auth, nil
+}
+
 func (w *MyWallet) BroadcastTransaction(ctx context.Context, tx *types.Transaction) error {
 	return nil
 }
 
+func (w *MyWallet) BroadcastReplacement(ctx context.Context, tx *types.Transaction) error {
+	return nil
+}
+
 func (w *MyWallet) EstimateGas(ctx context.Context, contractAddress gethCommon.Address, callData []byte) (uint64, error) {
 	return 0, nil
 }
@@ -257,6 +664,54 @@ func (w *MyWallet) TransactionReceipt(ctx context.Context, txHash gethCommon.Has
 	return nil, nil
 }
 
+func (w *MyWallet) BlockNumber(ctx context.Context) (uint64, error) {
+	return 0, nil
+}
+
+func (w *MyWallet) Simulate(ctx context.Context, msg ethereum.CallMsg) ([]byte, error) {
+	w.simulated = append(w.simulated, msg)
+	return w.simulateResult, w.simulateErr
+}
+
+func (w *MyWallet) CreateAccessList(ctx context.Context, msg ethereum.CallMsg) (types.AccessList, error) {
+	if w.accessList == nil {
+		return nil, fmt.Errorf("CreateAccessList not implemented in test mock")
+	}
+	return w.accessList, nil
+}
+
+func (w *MyWallet) TokenBalance(ctx context.Context, token gethCommon.Address) (*big.Int, error) {
+	return nil, nil
+}
+
+func (w *MyWallet) TokenBalances(ctx context.Context, tokens []gethCommon.Address) ([]*big.Int, error) {
+	return nil, nil
+}
+
+func (w *MyWallet) TokenAllowance(ctx context.Context, token gethCommon.Address, spender gethCommon.Address) (*big.Int, error) {
+	return nil, nil
+}
+
+func (w *MyWallet) TokenAllowances(ctx context.Context, tokens []gethCommon.Address, spender gethCommon.Address) ([]*big.Int, error) {
+	return nil, nil
+}
+
+func (w *MyWallet) TokenDecimals(ctx context.Context, token gethCommon.Address) (uint8, error) {
+	return 0, nil
+}
+
+func (w *MyWallet) TokenSymbol(ctx context.Context, token gethCommon.Address) (string, error) {
+	return "", nil
+}
+
+func (w *MyWallet) TokenApprove(ctx context.Context, token gethCommon.Address, spender gethCommon.Address, amount *big.Int) (*types.Transaction, error) {
+	return nil, nil
+}
+
+func (w *MyWallet) TokenTransfer(ctx context.Context, token gethCommon.Address, to gethCommon.Address, amount *big.Int) (*types.Transaction, error) {
+	return nil, nil
+}
+
 func (w *MyWallet) GetContractDetailsForPermitDaiLike(ctx context.Context, token gethCommon.Address, spender gethCommon.Address, deadline int64) (*common.ContractPermitDataDaiLike, error) {
 	return nil, nil
 }
@@ -265,12 +720,16 @@ func (w *MyWallet) TokenPermitDaiLike(cd common.ContractPermitDataDaiLike) (stri
 	return "", nil
 }
 
+func (w *MyWallet) DetectPermit(ctx context.Context, token gethCommon.Address) (*common.PermitSupport, error) {
+	return nil, nil
+}
+
 func (w *MyWallet) TokenPermit(cd common.ContractPermitData) (string, error) {
 	return "", nil
 }
 
 func (w *MyWallet) IsEIP1559Applicable() bool {
-	return true
+	return !w.legacy
 }
 
 func (w *MyWallet) ChainId() int64 {
@@ -6,9 +6,14 @@ import (
 
 	"github.com/ethereum/go-ethereum"
 	gethCommon "github.com/ethereum/go-ethereum/common"
+
+	"github.com/1inch/1inch-sdk-go/v4/common/revert"
 )
 
-func (w Wallet) Call(ctx context.Context, contractAddress gethCommon.Address, callData []byte) ([]byte, error) {
+func (w Wallet) Call(ctx context.Context, contractAddress gethCommon.Address, callData []byte) (resp []byte, err error) {
+	ctx, call := w.telemetry.StartRPC(ctx, "eth_call", w.ChainId())
+	defer func() { call.End(err) }()
+
 	if w.ethClient == nil {
 		return nil, fmt.Errorf("wallet has no node connection: create it with a node URL to make on-chain calls")
 	}
@@ -16,10 +21,27 @@ func (w Wallet) Call(ctx context.Context, contractAddress gethCommon.Address, ca
 		To:   &contractAddress,
 		Data: callData,
 	}
-	resp, err := w.ethClient.CallContract(ctx, nodeMsg, nil)
+	resp, err = w.ethClient.CallContract(ctx, nodeMsg, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to call contract: %w", err)
 	}
 
 	return resp, nil
 }
+
+func (w Wallet) Simulate(ctx context.Context, msg ethereum.CallMsg) (resp []byte, err error) {
+	ctx, call := w.telemetry.StartRPC(ctx, "eth_call", w.ChainId())
+	defer func() { call.End(err) }()
+
+	if w.ethClient == nil {
+		return nil, fmt.Errorf("wallet has no node connection: create it with a node URL to simulate transactions")
+	}
+	resp, err = w.ethClient.PendingCallContract(ctx, msg)
+	if err != nil {
+		if revertErr, ok := revert.FromError(err); ok {
+			return nil, fmt.Errorf("simulation failed: %w", revertErr)
+		}
+		return nil, fmt.Errorf("failed to simulate transaction: %w", err)
+	}
+	return resp, nil
+}
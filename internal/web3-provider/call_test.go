@@ -0,0 +1,79 @@
+package web3_provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/v4/common/revert"
+	"github.com/1inch/1inch-sdk-go/v4/constants"
+)
+
+func TestSimulate(t *testing.T) {
+	to := gethCommon.HexToAddress("0x111111125421ca6dc452d289314280a0f8842a65")
+
+	tests := []struct {
+		name            string
+		result          any
+		err             error
+		expected        []byte
+		expectedError   string
+		expectedErrorIs error
+	}{
+		{
+			name:     "Success",
+			result:   "0x01",
+			expected: []byte{0x01},
+		},
+		{
+			name:            "Custom error",
+			err:             revertError{data: "0x064a4ec6" + word(100) + word(250)},
+			expectedError:   "simulation failed: execution reverted: ReturnAmountIsNotEnough(100, 250)",
+			expectedErrorIs: revert.ErrReturnAmountIsNotEnough,
+		},
+		{
+			name:          "Node error",
+			err:           errors.New("header not found"),
+			expectedError: "failed to simulate transaction: header not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var block string
+			node := newTestNode(t, map[string]rpcHandler{
+				"eth_call": func(params []json.RawMessage) (any, error) {
+					require.NoError(t, json.Unmarshal(params[1], &block))
+					return tc.result, tc.err
+				},
+			})
+			w, err := DefaultWalletProvider(testPrivateKey, node.URL, constants.EthereumChainId)
+			require.NoError(t, err)
+
+			result, err := w.Simulate(context.Background(), ethereum.CallMsg{From: w.Address(), To: &to, Data: []byte{0x01}})
+			assert.Equal(t, "pending", block)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				if tc.expectedErrorIs != nil {
+					require.ErrorIs(t, err, tc.expectedErrorIs)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, hexutil.Bytes(tc.expected), hexutil.Bytes(result))
+		})
+	}
+}
+
+// word encodes n as a 32-byte ABI word without the 0x prefix
+func word(n int64) string {
+	return fmt.Sprintf("%064x", n)
+}
@@ -0,0 +1,205 @@
+package web3_provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+	"github.com/1inch/1inch-sdk-go/v4/constants"
+	transaction_builder "github.com/1inch/1inch-sdk-go/v4/internal/transaction-builder"
+	"github.com/1inch/1inch-sdk-go/v4/internal/web3-provider/multicall"
+)
+
+var nativeToken = gethCommon.HexToAddress(constants.NativeToken)
+
+// TokenBalance returns the wallet's balance of token. The native token address returns the
+// native balance.
+func (w Wallet) TokenBalance(ctx context.Context, token gethCommon.Address) (*big.Int, error) {
+	if token == nativeToken {
+		return w.Balance(ctx)
+	}
+	var balance *big.Int
+	if err := w.callToken(ctx, token, &balance, "balanceOf", w.Address()); err != nil {
+		return nil, err
+	}
+	return balance, nil
+}
+
+// TokenBalances returns the wallet's balances of tokens, in the same order, with one
+// multicall for all ERC-20 tokens.
+func (w Wallet) TokenBalances(ctx context.Context, tokens []gethCommon.Address) ([]*big.Int, error) {
+	balances := make([]*big.Int, len(tokens))
+	var erc20Tokens []gethCommon.Address
+	var erc20Indexes []int
+	for i, token := range tokens {
+		if token == nativeToken {
+			balance, err := w.Balance(ctx)
+			if err != nil {
+				return nil, err
+			}
+			balances[i] = balance
+			continue
+		}
+		erc20Tokens = append(erc20Tokens, token)
+		erc20Indexes = append(erc20Indexes, i)
+	}
+
+	results, err := w.multicallTokens(ctx, erc20Tokens, "balanceOf", w.Address())
+	if err != nil {
+		return nil, err
+	}
+	for i, result := range results {
+		balances[erc20Indexes[i]] = result.(*big.Int)
+	}
+	return balances, nil
+}
+
+// TokenAllowance returns the amount of token that spender may transfer from the wallet.
+func (w Wallet) TokenAllowance(ctx context.Context, token gethCommon.Address, spender gethCommon.Address) (*big.Int, error) {
+	var allowance *big.Int
+	if err := w.callToken(ctx, token, &allowance, "allowance", w.Address(), spender); err != nil {
+		return nil, err
+	}
+	return allowance, nil
+}
+
+// TokenAllowances returns the allowances of spender over tokens, in the same order, with one multicall.
+func (w Wallet) TokenAllowances(ctx context.Context, tokens []gethCommon.Address, spender gethCommon.Address) ([]*big.Int, error) {
+	results, err := w.multicallTokens(ctx, tokens, "allowance", w.Address(), spender)
+	if err != nil {
+		return nil, err
+	}
+	allowances := make([]*big.Int, len(results))
+	for i, result := range results {
+		allowances[i] = result.(*big.Int)
+	}
+	return allowances, nil
+}
+
+func (w Wallet) TokenDecimals(ctx context.Context, token gethCommon.Address) (uint8, error) {
+	var decimals uint8
+	if err := w.callToken(ctx, token, &decimals, "decimals"); err != nil {
+		return 0, err
+	}
+	return decimals, nil
+}
+
+func (w Wallet) TokenSymbol(ctx context.Context, token gethCommon.Address) (string, error) {
+	var symbol string
+	if err := w.callToken(ctx, token, &symbol, "symbol"); err != nil {
+		return "", err
+	}
+	return symbol, nil
+}
+
+// TokenApprove allows spender to transfer amount of token from the wallet. The transaction
+// is signed and broadcast; it is returned so the caller can wait for its receipt.
+func (w Wallet) TokenApprove(ctx context.Context, token gethCommon.Address, spender gethCommon.Address, amount *big.Int) (*types.Transaction, error) {
+	data, err := w.erc20ABI.Pack("approve", spender, amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack approve: %w", err)
+	}
+	return w.sendTokenTransaction(ctx, token, data)
+}
+
+// TokenTransfer sends amount of token from the wallet to the given address. The transaction
+// is signed and broadcast; it is returned so the caller can wait for its receipt.
+func (w Wallet) TokenTransfer(ctx context.Context, token gethCommon.Address, to gethCommon.Address, amount *big.Int) (*types.Transaction, error) {
+	data, err := w.erc20ABI.Pack("transfer", to, amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack transfer: %w", err)
+	}
+	return w.sendTokenTransaction(ctx, token, data)
+}
+
+// callToken calls a view method of the ERC-20 contract at token and unpacks its single output into out
+func (w Wallet) callToken(ctx context.Context, token gethCommon.Address, out any, method string, args ...any) error {
+	data, err := w.erc20ABI.Pack(method, args...)
+	if err != nil {
+		return fmt.Errorf("failed to pack %s: %w", method, err)
+	}
+	resp, err := w.Call(ctx, token, data)
+	if err != nil {
+		return err
+	}
+	if len(resp) == 0 {
+		return fmt.Errorf("token %s returned no data for %s: it may not be an ERC-20 contract", token.Hex(), method)
+	}
+	if err := w.erc20ABI.UnpackIntoInterface(out, method, resp); err != nil {
+		return fmt.Errorf("failed to unpack %s of token %s: %w", method, token.Hex(), err)
+	}
+	return nil
+}
+
+// multicallTokens calls the same view method on every token in one multicall and returns the
+// first output of each call
+func (w Wallet) multicallTokens(ctx context.Context, tokens []gethCommon.Address, method string, args ...any) ([]any, error) {
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	if w.multicall == nil {
+		return nil, fmt.Errorf("wallet has no node connection: create it with a node URL to make on-chain calls")
+	}
+	data, err := w.erc20ABI.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s: %w", method, err)
+	}
+	callData := make([]multicall.CallData, len(tokens))
+	for i, token := range tokens {
+		callData[i] = multicall.BuildCallData(token, data, 0)
+	}
+
+	resp, err := w.multicall.Execute(ctx, callData)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp) != len(tokens) {
+		return nil, fmt.Errorf("multicall returned %d results for %d calls", len(resp), len(tokens))
+	}
+	results := make([]any, len(tokens))
+	for i, token := range tokens {
+		// The multicall contract does not report failures: a reverted call leaves its result
+		// empty or holding the revert data, which fails to unpack below
+		if len(resp[i]) == 0 {
+			return nil, fmt.Errorf("token %s returned no data for %s: it may not be an ERC-20 contract", token.Hex(), method)
+		}
+		outputs, err := w.erc20ABI.Unpack(method, resp[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to unpack %s of token %s: %w", method, token.Hex(), err)
+		}
+		results[i] = outputs[0]
+	}
+	return results, nil
+}
+
+// sendTokenTransaction builds a transaction calling token with data at the fees of the wallet's
+// fee strategy, then signs and broadcasts it
+func (w Wallet) sendTokenTransaction(ctx context.Context, token gethCommon.Address, data []byte) (*types.Transaction, error) {
+	gas, err := w.GetGasEstimate(ctx, ethereum.CallMsg{
+		From: w.Address(),
+		To:   &token,
+		Data: data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+
+	builder := transaction_builder.NewFactory(w, common.WithWalletLogger(w.logger), common.WithFeeStrategy(w.feeStrategy), common.WithNonceManager(w.nonces)).New()
+	tx, err := builder.SetTo(&token).SetData(data).SetGas(gas).Build(ctx)
+	if err != nil {
+		return nil, err
+	}
+	signed, err := w.Sign(tx)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.BroadcastTransaction(ctx, signed); err != nil {
+		return nil, err
+	}
+	return signed, nil
+}
@@ -0,0 +1,327 @@
+package web3_provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+	"github.com/1inch/1inch-sdk-go/v4/common/fees"
+	"github.com/1inch/1inch-sdk-go/v4/constants"
+	"github.com/1inch/1inch-sdk-go/v4/internal/web3-provider/multicall"
+)
+
+var (
+	usdc    = gethCommon.HexToAddress("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")
+	weth    = gethCommon.HexToAddress("0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2")
+	spender = gethCommon.HexToAddress("0x111111125421ca6dc452d289314280a0f8842a65")
+	// notAToken reverts every call
+	notAToken = gethCommon.HexToAddress("0x000000000000000000000000000000000000dead")
+)
+
+type testToken struct {
+	balance   *big.Int
+	allowance *big.Int
+	decimals  uint8
+	symbol    string
+}
+
+// newTokenNode serves eth_call for the given tokens, directly and through the Ethereum
+// multicall contract, plus the methods needed to send a transaction
+func newTokenNode(t *testing.T, tokens map[gethCommon.Address]testToken, sent *[]*types.Transaction) *testNode {
+	erc20ABI, err := abi.JSON(strings.NewReader(constants.Erc20ABI))
+	require.NoError(t, err)
+	multicallABI, err := abi.JSON(strings.NewReader(multicall.Multicallv2abiABI))
+	require.NoError(t, err)
+	multicallAddress := gethCommon.HexToAddress("0x8d035edd8e09c3283463dade67cc0d49d6868063")
+
+	callToken := func(to gethCommon.Address, data []byte) ([]byte, error) {
+		token, ok := tokens[to]
+		if !ok {
+			return nil, errors.New("execution reverted")
+		}
+		method, err := erc20ABI.MethodById(data[:4])
+		require.NoError(t, err)
+		switch method.Name {
+		case "balanceOf":
+			return method.Outputs.Pack(token.balance)
+		case "allowance":
+			args, err := method.Inputs.Unpack(data[4:])
+			require.NoError(t, err)
+			require.Equal(t, spender, args[1])
+			return method.Outputs.Pack(token.allowance)
+		case "decimals":
+			return method.Outputs.Pack(token.decimals)
+		case "symbol":
+			return method.Outputs.Pack(token.symbol)
+		}
+		return nil, errors.New("execution reverted")
+	}
+
+	return newTestNode(t, map[string]rpcHandler{
+		"eth_call": func(params []json.RawMessage) (any, error) {
+			var call struct {
+				To    gethCommon.Address `json:"to"`
+				Input hexutil.Bytes      `json:"input"`
+				Data  hexutil.Bytes      `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(params[0], &call))
+			if call.Input == nil {
+				call.Input = call.Data
+			}
+			if call.To != multicallAddress {
+				resp, err := callToken(call.To, call.Input)
+				return hexutil.Bytes(resp), err
+			}
+
+			args, err := multicallABI.Methods["multicall"].Inputs.Unpack(call.Input[4:])
+			require.NoError(t, err)
+			calls := args[0].([]struct {
+				To   gethCommon.Address `json:"to"`
+				Data []byte             `json:"data"`
+			})
+			results := make([][]byte, len(calls))
+			for i, c := range calls {
+				results[i], _ = callToken(c.To, c.Data)
+			}
+			resp, err := multicallABI.Methods["multicall"].Outputs.Pack(results)
+			require.NoError(t, err)
+			return hexutil.Bytes(resp), nil
+		},
+		"eth_getBalance": func(params []json.RawMessage) (any, error) {
+			return "0xde0b6b3a7640000", nil
+		},
+		"eth_estimateGas":          func(params []json.RawMessage) (any, error) { return "0xb411", nil },
+		"eth_maxPriorityFeePerGas": func(params []json.RawMessage) (any, error) { return "0x3b9aca00", nil },
+		"eth_gasPrice":             func(params []json.RawMessage) (any, error) { return "0x2540be400", nil },
+		"eth_getTransactionCount":  func(params []json.RawMessage) (any, error) { return "0x4", nil },
+		"eth_sendRawTransaction": func(params []json.RawMessage) (any, error) {
+			var raw hexutil.Bytes
+			require.NoError(t, json.Unmarshal(params[0], &raw))
+			tx := new(types.Transaction)
+			require.NoError(t, tx.UnmarshalBinary(raw))
+			*sent = append(*sent, tx)
+			return tx.Hash(), nil
+		},
+	})
+}
+
+func TestTokenViews(t *testing.T) {
+	tokens := map[gethCommon.Address]testToken{
+		usdc: {balance: big.NewInt(2_500_000), allowance: big.NewInt(0), decimals: 6, symbol: "USDC"},
+		weth: {balance: big.NewInt(3e17), allowance: new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1)), decimals: 18, symbol: "WETH"},
+	}
+	node := newTokenNode(t, tokens, nil)
+	w, err := DefaultWalletProvider(testPrivateKey, node.URL, constants.EthereumChainId)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	balance, err := w.TokenBalance(ctx, usdc)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(2_500_000), balance)
+
+	balance, err = w.TokenBalance(ctx, gethCommon.HexToAddress(constants.NativeToken))
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1e18), balance)
+
+	allowance, err := w.TokenAllowance(ctx, weth, spender)
+	require.NoError(t, err)
+	assert.Equal(t, tokens[weth].allowance, allowance)
+
+	decimals, err := w.TokenDecimals(ctx, usdc)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(6), decimals)
+
+	symbol, err := w.TokenSymbol(ctx, weth)
+	require.NoError(t, err)
+	assert.Equal(t, "WETH", symbol)
+
+	_, err = w.TokenDecimals(ctx, notAToken)
+	require.ErrorContains(t, err, "execution reverted")
+}
+
+func TestTokenBatches(t *testing.T) {
+	tokens := map[gethCommon.Address]testToken{
+		usdc: {balance: big.NewInt(2_500_000), allowance: big.NewInt(10)},
+		weth: {balance: big.NewInt(3e17), allowance: big.NewInt(0)},
+	}
+
+	tests := []struct {
+		name               string
+		tokens             []gethCommon.Address
+		expectedBalances   []*big.Int
+		expectedAllowances []*big.Int
+		expectedError      string
+	}{
+		{
+			name:               "ERC-20 tokens",
+			tokens:             []gethCommon.Address{weth, usdc},
+			expectedBalances:   []*big.Int{big.NewInt(3e17), big.NewInt(2_500_000)},
+			expectedAllowances: []*big.Int{big.NewInt(0), big.NewInt(10)},
+		},
+		{
+			name:             "Native token balance",
+			tokens:           []gethCommon.Address{usdc, gethCommon.HexToAddress(constants.NativeToken)},
+			expectedBalances: []*big.Int{big.NewInt(2_500_000), big.NewInt(1e18)},
+		},
+		{
+			name:          "Reverting token",
+			tokens:        []gethCommon.Address{usdc, notAToken},
+			expectedError: "token 0x000000000000000000000000000000000000dEaD returned no data for",
+		},
+		{
+			name:               "No tokens",
+			tokens:             nil,
+			expectedBalances:   []*big.Int{},
+			expectedAllowances: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			node := newTokenNode(t, tokens, nil)
+			w, err := DefaultWalletProvider(testPrivateKey, node.URL, constants.EthereumChainId)
+			require.NoError(t, err)
+			ctx := context.Background()
+
+			balances, err := w.TokenBalances(ctx, tc.tokens)
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, bigStrings(tc.expectedBalances), bigStrings(balances))
+
+			if tc.expectedAllowances != nil {
+				allowances, err := w.TokenAllowances(ctx, tc.tokens, spender)
+				require.NoError(t, err)
+				assert.Equal(t, bigStrings(tc.expectedAllowances), bigStrings(allowances))
+			}
+
+			ethCalls := 0
+			for _, method := range node.methodCalls() {
+				if method == "eth_call" {
+					ethCalls++
+				}
+			}
+			assert.LessOrEqual(t, ethCalls, 2)
+		})
+	}
+}
+
+func TestTokenTransactions(t *testing.T) {
+	erc20ABI, err := abi.JSON(strings.NewReader(constants.Erc20ABI))
+	require.NoError(t, err)
+	amount := big.NewInt(1_000_000)
+
+	tests := []struct {
+		name         string
+		send         func(w *Wallet) (*types.Transaction, error)
+		expectedData []byte
+	}{
+		{
+			name: "Approve",
+			send: func(w *Wallet) (*types.Transaction, error) {
+				return w.TokenApprove(context.Background(), usdc, spender, amount)
+			},
+			expectedData: mustPack(t, erc20ABI, "approve", spender, amount),
+		},
+		{
+			name: "Transfer",
+			send: func(w *Wallet) (*types.Transaction, error) {
+				return w.TokenTransfer(context.Background(), usdc, spender, amount)
+			},
+			expectedData: mustPack(t, erc20ABI, "transfer", spender, amount),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var sent []*types.Transaction
+			node := newTokenNode(t, nil, &sent)
+			w, err := DefaultWalletProvider(testPrivateKey, node.URL, constants.EthereumChainId)
+			require.NoError(t, err)
+
+			tx, err := tc.send(w)
+			require.NoError(t, err)
+			require.Len(t, sent, 1)
+			assert.Equal(t, tx.Hash(), sent[0].Hash())
+
+			assert.Equal(t, usdc, *tx.To())
+			assert.Equal(t, tc.expectedData, tx.Data())
+			assert.Equal(t, uint64(4), tx.Nonce())
+			assert.Equal(t, uint64(0xb411), tx.Gas())
+			assert.Equal(t, uint8(types.DynamicFeeTxType), tx.Type())
+			sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+			require.NoError(t, err)
+			assert.Equal(t, w.Address(), sender)
+		})
+	}
+}
+
+func mustPack(t *testing.T, contractABI abi.ABI, method string, args ...any) []byte {
+	data, err := contractABI.Pack(method, args...)
+	require.NoError(t, err)
+	return data
+}
+
+// bigStrings formats values for comparison: equal big.Int values may differ in their internal representation
+func bigStrings(values []*big.Int) []string {
+	strs := make([]string, len(values))
+	for i, v := range values {
+		strs[i] = v.String()
+	}
+	return strs
+}
+
+func TestTokenApproveFeeStrategy(t *testing.T) {
+	amount := big.NewInt(1_000_000)
+
+	tests := []struct {
+		name              string
+		maxFeePerGas      *big.Int
+		expectedGasFeeCap *big.Int
+		expectedErrorIs   error
+	}{
+		{
+			name:              "Under the ceiling",
+			maxFeePerGas:      big.NewInt(30e9),
+			expectedGasFeeCap: big.NewInt(20e9),
+		},
+		{
+			name:            "Above the ceiling",
+			maxFeePerGas:    big.NewInt(15e9),
+			expectedErrorIs: fees.ErrAboveCeiling,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var sent []*types.Transaction
+			node := newTokenNode(t, nil, &sent)
+			w, err := DefaultWalletProvider(testPrivateKey, node.URL, constants.EthereumChainId,
+				common.WithFeeStrategy(fees.MaxFee(fees.Node(), tc.maxFeePerGas)))
+			require.NoError(t, err)
+
+			tx, err := w.TokenApprove(context.Background(), usdc, spender, amount)
+			if tc.expectedErrorIs != nil {
+				require.ErrorIs(t, err, tc.expectedErrorIs)
+				assert.Empty(t, sent)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, sent, 1)
+			assert.Equal(t, tc.expectedGasFeeCap, tx.GasFeeCap())
+		})
+	}
+}
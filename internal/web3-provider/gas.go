@@ -5,23 +5,106 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/1inch/1inch-sdk-go/v4/common/revert"
 	"github.com/1inch/1inch-sdk-go/v4/constants"
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
-func (w Wallet) GetGasTipCap(ctx context.Context) (*big.Int, error) {
+func (w Wallet) GetGasTipCap(ctx context.Context) (tipCap *big.Int, err error) {
 	if !w.IsEIP1559Applicable() {
 		return nil, fmt.Errorf("unsupported: EIP-1559 on this chain")
 	}
+	ctx, call := w.telemetry.StartRPC(ctx, "eth_maxPriorityFeePerGas", w.ChainId())
+	defer func() { call.End(err) }()
+
 	return w.ethClient.SuggestGasTipCap(ctx)
 }
 
-func (w Wallet) GetGasPrice(ctx context.Context) (*big.Int, error) {
+func (w Wallet) GetGasPrice(ctx context.Context) (gasPrice *big.Int, err error) {
+	ctx, call := w.telemetry.StartRPC(ctx, "eth_gasPrice", w.ChainId())
+	defer func() { call.End(err) }()
+
 	return w.ethClient.SuggestGasPrice(ctx)
 }
 
-func (w Wallet) GetGasEstimate(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
-	return w.ethClient.EstimateGas(ctx, msg)
+func (w Wallet) GetGasEstimate(ctx context.Context, msg ethereum.CallMsg) (gas uint64, err error) {
+	ctx, call := w.telemetry.StartRPC(ctx, "eth_estimateGas", w.ChainId())
+	defer func() { call.End(err) }()
+
+	gas, err = w.ethClient.EstimateGas(ctx, msg)
+	if revertErr, ok := revert.FromError(err); ok {
+		return 0, fmt.Errorf("failed to estimate gas: %w", revertErr)
+	}
+	return gas, err
+}
+
+// CreateAccessList asks the node which addresses and storage slots msg touches. Declaring them
+// in the transaction makes the first access to each one cheaper.
+func (w Wallet) CreateAccessList(ctx context.Context, msg ethereum.CallMsg) (accessList types.AccessList, err error) {
+	ctx, call := w.telemetry.StartRPC(ctx, "eth_createAccessList", w.ChainId())
+	defer func() { call.End(err) }()
+
+	if w.ethClient == nil {
+		return nil, fmt.Errorf("wallet has no node connection: create it with a node URL to create access lists")
+	}
+	var result struct {
+		AccessList *types.AccessList `json:"accessList"`
+		Error      string            `json:"error"`
+	}
+	if err := w.ethClient.Client().CallContext(ctx, &result, "eth_createAccessList", accessListArg(msg), "pending"); err != nil {
+		return nil, fmt.Errorf("failed to create access list: %w", err)
+	}
+	if result.Error != "" {
+		return nil, fmt.Errorf("failed to create access list: %s", result.Error)
+	}
+	if result.AccessList == nil {
+		return types.AccessList{}, nil
+	}
+	return *result.AccessList, nil
+}
+
+// accessListArg encodes msg as the transaction object of eth_createAccessList, like the call
+// argument ethclient builds for eth_call and eth_estimateGas
+func accessListArg(msg ethereum.CallMsg) map[string]interface{} {
+	arg := map[string]interface{}{
+		"from": msg.From,
+	}
+	if msg.To != nil {
+		arg["to"] = msg.To
+	}
+	if len(msg.Data) > 0 {
+		arg["input"] = hexutil.Bytes(msg.Data)
+	}
+	if msg.Value != nil {
+		arg["value"] = (*hexutil.Big)(msg.Value)
+	}
+	if msg.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(msg.Gas)
+	}
+	if msg.GasPrice != nil {
+		arg["gasPrice"] = (*hexutil.Big)(msg.GasPrice)
+	}
+	if msg.GasFeeCap != nil {
+		arg["maxFeePerGas"] = (*hexutil.Big)(msg.GasFeeCap)
+	}
+	if msg.GasTipCap != nil {
+		arg["maxPriorityFeePerGas"] = (*hexutil.Big)(msg.GasTipCap)
+	}
+	if msg.AccessList != nil {
+		arg["accessList"] = msg.AccessList
+	}
+	if msg.BlobGasFeeCap != nil {
+		arg["maxFeePerBlobGas"] = (*hexutil.Big)(msg.BlobGasFeeCap)
+	}
+	if msg.BlobHashes != nil {
+		arg["blobVersionedHashes"] = msg.BlobHashes
+	}
+	if msg.AuthorizationList != nil {
+		arg["authorizationList"] = msg.AuthorizationList
+	}
+	return arg
 }
 
 func (w Wallet) IsEIP1559Applicable() bool {
@@ -0,0 +1,120 @@
+package web3_provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/v4/common/revert"
+	"github.com/1inch/1inch-sdk-go/v4/constants"
+)
+
+func TestCreateAccessList(t *testing.T) {
+	to := gethCommon.HexToAddress("0x000000000000000000000000000000000000dead")
+	accessList := types.AccessList{{
+		Address:     to,
+		StorageKeys: []gethCommon.Hash{gethCommon.HexToHash("0x01")},
+	}}
+
+	tests := []struct {
+		name               string
+		result             any
+		err                error
+		expectedAccessList types.AccessList
+		expectedError      string
+	}{
+		{
+			name:               "Access list",
+			result:             map[string]any{"accessList": accessList, "gasUsed": "0x5208"},
+			expectedAccessList: accessList,
+		},
+		{
+			name:               "Empty access list",
+			result:             map[string]any{"gasUsed": "0x5208"},
+			expectedAccessList: types.AccessList{},
+		},
+		{
+			name:          "Execution error",
+			result:        map[string]any{"accessList": accessList, "gasUsed": "0x5208", "error": "execution reverted"},
+			expectedError: "failed to create access list: execution reverted",
+		},
+		{
+			name:          "Node error",
+			err:           errors.New("method not supported"),
+			expectedError: "failed to create access list: method not supported",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var call struct {
+				From                 gethCommon.Address  `json:"from"`
+				To                   *gethCommon.Address `json:"to"`
+				Input                hexutil.Bytes       `json:"input"`
+				Value                *hexutil.Big        `json:"value"`
+				MaxFeePerGas         *hexutil.Big        `json:"maxFeePerGas"`
+				MaxPriorityFeePerGas *hexutil.Big        `json:"maxPriorityFeePerGas"`
+				AccessList           types.AccessList    `json:"accessList"`
+			}
+			var block string
+			node := newTestNode(t, map[string]rpcHandler{
+				"eth_createAccessList": func(params []json.RawMessage) (any, error) {
+					require.Len(t, params, 2)
+					require.NoError(t, json.Unmarshal(params[0], &call))
+					require.NoError(t, json.Unmarshal(params[1], &block))
+					return tc.result, tc.err
+				},
+			})
+			w, err := DefaultWalletProvider(testPrivateKey, node.URL, constants.EthereumChainId)
+			require.NoError(t, err)
+
+			list, err := w.CreateAccessList(context.Background(), ethereum.CallMsg{
+				From:       w.Address(),
+				To:         &to,
+				Value:      big.NewInt(7),
+				Data:       []byte{0x01, 0x02},
+				GasFeeCap:  big.NewInt(30),
+				GasTipCap:  big.NewInt(2),
+				AccessList: accessList,
+			})
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedAccessList, list)
+
+			assert.Equal(t, w.Address(), call.From)
+			assert.Equal(t, &to, call.To)
+			assert.Equal(t, hexutil.Bytes{0x01, 0x02}, call.Input)
+			assert.Equal(t, big.NewInt(7), call.Value.ToInt())
+			assert.Equal(t, big.NewInt(30), call.MaxFeePerGas.ToInt())
+			assert.Equal(t, big.NewInt(2), call.MaxPriorityFeePerGas.ToInt())
+			assert.Equal(t, accessList, call.AccessList)
+			assert.Equal(t, "pending", block)
+		})
+	}
+}
+
+func TestGetGasEstimateRevert(t *testing.T) {
+	node := newTestNode(t, map[string]rpcHandler{
+		"eth_estimateGas": func(params []json.RawMessage) (any, error) {
+			return nil, revertError{data: "0x70a03f48"}
+		},
+	})
+	w, err := DefaultWalletProvider(testPrivateKey, node.URL, constants.EthereumChainId)
+	require.NoError(t, err)
+
+	_, err = w.GetGasEstimate(context.Background(), ethereum.CallMsg{From: w.Address()})
+	require.EqualError(t, err, "failed to estimate gas: execution reverted: TransferFromMakerToTakerFailed()")
+	require.ErrorIs(t, err, revert.ErrTransferFromMakerToTakerFailed)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/ethclient"
 
+	multicall3 "github.com/1inch/1inch-sdk-go/v4/common/multicall"
 	"github.com/1inch/1inch-sdk-go/v4/constants"
 )
 
@@ -40,6 +41,8 @@ type Client struct {
 	client          *ethclient.Client
 	contractAddress *common.Address
 	contractABI     *abi.ABI
+	// aggregate3 is set on chains without a 1inch multicall contract
+	aggregate3 *multicall3.Client
 }
 
 func NewMulticall(client *ethclient.Client, chainId uint64) (*Client, error) {
@@ -71,7 +74,12 @@ func NewMulticall(client *ethclient.Client, chainId uint64) (*Client, error) {
 	case constants.BaseChainId:
 		addressRaw = multicallContractBase
 	default:
-		return nil, fmt.Errorf("unsupported chain ID: %d", chainId)
+		// Other chains fall back to Multicall3, which is deployed at the same address almost everywhere
+		aggregate3, err := multicall3.NewClient(client, chainId, multicall3.Config{})
+		if err != nil {
+			return nil, err
+		}
+		return &Client{client: client, aggregate3: aggregate3}, nil
 	}
 
 	helperContractAddress := common.HexToAddress(addressRaw)
@@ -96,7 +104,13 @@ func BuildCallData(to common.Address, data []byte, gas uint64, opts ...string) (
 	return r
 }
 
+// Execute calls every entry of callData in one multicall. A call that reverts gets empty or
+// revert data as its result instead of failing the whole multicall.
 func (m Client) Execute(ctx context.Context, callData []CallData) ([][]byte, error) {
+	if m.aggregate3 != nil {
+		return m.executeAggregate3(ctx, callData)
+	}
+
 	var requests []request
 	for _, d := range callData {
 		requests = append(requests, request{
@@ -134,3 +148,26 @@ func (m Client) Execute(ctx context.Context, callData []CallData) ([][]byte, err
 
 	return multicallResponse.Results, nil
 }
+
+func (m Client) executeAggregate3(ctx context.Context, callData []CallData) ([][]byte, error) {
+	calls := make([]multicall3.Call, len(callData))
+	for i, d := range callData {
+		calls[i] = multicall3.Call{
+			Target:       common.HexToAddress(d.To),
+			CallData:     common.FromHex(d.Data),
+			AllowFailure: true,
+			Gas:          d.Gas,
+		}
+	}
+	results, err := m.aggregate3.Aggregate3(ctx, calls)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([][]byte, len(results))
+	for i, r := range results {
+		if r.Success {
+			resp[i] = r.ReturnData
+		}
+	}
+	return resp, nil
+}
@@ -5,6 +5,9 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/v4/constants"
 )
 
 func TestBuildCallData(t *testing.T) {
@@ -50,3 +53,29 @@ func TestBuildCallData(t *testing.T) {
 		})
 	}
 }
+
+func TestNewMulticall(t *testing.T) {
+	tests := []struct {
+		name              string
+		chainId           uint64
+		expectedAggregate bool
+	}{
+		{
+			name:    "1inch multicall contract",
+			chainId: constants.EthereumChainId,
+		},
+		{
+			name:              "Multicall3 fallback for other chains",
+			chainId:           59144,
+			expectedAggregate: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := NewMulticall(nil, tc.chainId)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedAggregate, m.aggregate3 != nil)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package web3_provider
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// rpcHandler answers a JSON-RPC method. A non-nil error is returned to the client as a JSON-RPC error.
+type rpcHandler func(params []json.RawMessage) (any, error)
+
+// testNode is a minimal JSON-RPC node serving the handlers it is given.
+type testNode struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	handlers map[string]rpcHandler
+	calls    []string
+}
+
+func newTestNode(t *testing.T, handlers map[string]rpcHandler) *testNode {
+	t.Helper()
+	node := &testNode{handlers: handlers}
+	node.Server = httptest.NewServer(http.HandlerFunc(node.serve))
+	t.Cleanup(node.Close)
+	return node
+}
+
+type rpcRequest struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data,omitempty"`
+}
+
+// revertError makes a handler answer like a node whose execution reverted with data
+type revertError struct {
+	data string
+}
+
+func (e revertError) Error() string {
+	return "execution reverted"
+}
+
+func (n *testNode) serve(w http.ResponseWriter, r *http.Request) {
+	var body json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if len(body) > 0 && body[0] == '[' {
+		var batch []rpcRequest
+		if err := json.Unmarshal(body, &batch); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		responses := make([]rpcResponse, 0, len(batch))
+		for _, req := range batch {
+			responses = append(responses, n.handle(req))
+		}
+		_ = json.NewEncoder(w).Encode(responses)
+		return
+	}
+
+	var req rpcRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(n.handle(req))
+}
+
+func (n *testNode) handle(req rpcRequest) rpcResponse {
+	n.mu.Lock()
+	n.calls = append(n.calls, req.Method)
+	handler, ok := n.handlers[req.Method]
+	n.mu.Unlock()
+
+	resp := rpcResponse{JSONRPC: "2.0", ID: req.ID}
+	if !ok {
+		resp.Error = &rpcError{Code: -32601, Message: "method not found: " + req.Method}
+		return resp
+	}
+	result, err := handler(req.Params)
+	var reverted revertError
+	if errors.As(err, &reverted) {
+		resp.Error = &rpcError{Code: 3, Message: err.Error(), Data: reverted.data}
+		return resp
+	}
+	if err != nil {
+		resp.Error = &rpcError{Code: -32000, Message: err.Error()}
+		return resp
+	}
+	resp.Result = result
+	return resp
+}
+
+// methodCalls returns the JSON-RPC methods received so far, in order.
+func (n *testNode) methodCalls() []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]string(nil), n.calls...)
+}
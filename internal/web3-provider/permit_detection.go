@@ -0,0 +1,239 @@
+package web3_provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+	"github.com/1inch/1inch-sdk-go/v4/constants"
+	"github.com/1inch/1inch-sdk-go/v4/internal/web3-provider/multicall"
+)
+
+var eip712DomainABI, eip712DomainABIErr = abi.JSON(strings.NewReader(constants.Eip712DomainABI))
+
+var (
+	erc2612PermitTypeHash = crypto.Keccak256Hash([]byte("Permit(address owner,address spender,uint256 value,uint256 nonce,uint256 deadline)"))
+	daiPermitTypeHash     = crypto.Keccak256Hash([]byte("Permit(address holder,address spender,uint256 nonce,uint256 expiry,bool allowed)"))
+)
+
+// Bits of the EIP-5267 fields bitmap
+const (
+	domainFieldName = 1 << iota
+	domainFieldVersion
+	domainFieldChainId
+	domainFieldVerifyingContract
+	domainFieldSalt
+)
+
+// Versions tried when the token has no version() getter, or its domain uses another one
+var fallbackDomainVersions = []string{"1", "2"}
+
+// DetectPermit reads everything permit signing needs in one multicall. A token without
+// PERMIT_TYPEHASH is taken as ERC-2612 once its domain separator is matched.
+func (w Wallet) DetectPermit(ctx context.Context, token gethCommon.Address) (*common.PermitSupport, error) {
+	if eip712DomainABIErr != nil {
+		return nil, fmt.Errorf("failed to parse EIP-712 domain ABI: %w", eip712DomainABIErr)
+	}
+	if w.multicall == nil {
+		return nil, fmt.Errorf("wallet has no node connection: create it with a node URL to make on-chain calls")
+	}
+
+	type probe struct {
+		abi    *abi.ABI
+		method string
+		args   []any
+	}
+	probes := []probe{
+		{&eip712DomainABI, "eip712Domain", nil},
+		{&eip712DomainABI, "DOMAIN_SEPARATOR", nil},
+		{w.erc20ABI, "nonces", []any{w.Address()}},
+		{w.erc20ABI, "PERMIT_TYPEHASH", nil},
+		{w.erc20ABI, "name", nil},
+		{w.erc20ABI, "version", nil},
+	}
+	callData := make([]multicall.CallData, len(probes))
+	for i, p := range probes {
+		data, err := p.abi.Pack(p.method, p.args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pack %s: %w", p.method, err)
+		}
+		callData[i] = multicall.BuildCallData(token, data, 0)
+	}
+	resp, err := w.multicall.Execute(ctx, callData)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp) != len(probes) {
+		return nil, fmt.Errorf("multicall returned %d results for %d calls", len(resp), len(probes))
+	}
+	// A method the token lacks leaves its result empty or holding revert data, which is never
+	// a whole number of ABI words
+	results := make([][]any, len(probes))
+	for i, p := range probes {
+		if len(resp[i]) == 0 || len(resp[i])%32 != 0 {
+			continue
+		}
+		if outputs, err := p.abi.Unpack(p.method, resp[i]); err == nil {
+			results[i] = outputs
+		}
+	}
+	eip5267, domainSeparator, nonce, typeHash, name, version := results[0], results[1], results[2], results[3], results[4], results[5]
+
+	support := &common.PermitSupport{
+		Kind:  common.PermitUnsupported,
+		Token: token,
+		Owner: w.Address(),
+	}
+	if domainSeparator == nil {
+		support.Reason = "token has no DOMAIN_SEPARATOR"
+		return support, nil
+	}
+	support.DomainSeparator = domainSeparator[0].([32]byte)
+	if nonce == nil {
+		support.Reason = "token has no nonces(address)"
+		return support, nil
+	}
+	support.Nonce = nonce[0].(*big.Int)
+
+	kind := common.PermitERC2612
+	if typeHash != nil {
+		switch gethCommon.Hash(typeHash[0].([32]byte)) {
+		case erc2612PermitTypeHash:
+		case daiPermitTypeHash:
+			kind = common.PermitDaiLike
+		default:
+			support.Reason = fmt.Sprintf("token has an unknown PERMIT_TYPEHASH %s", gethCommon.Hash(typeHash[0].([32]byte)).Hex())
+			return support, nil
+		}
+	}
+
+	var candidates []apitypes.TypedDataDomain
+	fromEIP5267 := false
+	if eip5267 != nil {
+		if domain, ok := eip5267Domain(eip5267); ok {
+			candidates = append(candidates, domain)
+			fromEIP5267 = true
+		}
+	}
+	if name != nil {
+		candidates = append(candidates, w.guessDomains(token, name[0].(string), version)...)
+	}
+
+	for i, domain := range candidates {
+		separator, err := hashDomain(domain)
+		if err != nil || separator != support.DomainSeparator {
+			continue
+		}
+		support.Domain = domain
+		support.IsDomainWithoutVersion = domain.Version == ""
+		support.FromEIP5267 = fromEIP5267 && i == 0
+		if reason := unsignableDomain(domain, token, w.chainId); reason != "" {
+			support.Reason = reason
+			return support, nil
+		}
+		support.Kind = kind
+		return support, nil
+	}
+	support.Reason = "no EIP-712 domain built from the token's name and version matches its DOMAIN_SEPARATOR"
+	return support, nil
+}
+
+// eip5267Domain returns the domain reported by eip712Domain(). Domains with extensions cannot
+// be reconstructed.
+func eip5267Domain(outputs []any) (apitypes.TypedDataDomain, bool) {
+	fields := outputs[0].([1]byte)[0]
+	if len(outputs[6].([]*big.Int)) != 0 {
+		return apitypes.TypedDataDomain{}, false
+	}
+	var domain apitypes.TypedDataDomain
+	if fields&domainFieldName != 0 {
+		domain.Name = outputs[1].(string)
+	}
+	if fields&domainFieldVersion != 0 {
+		domain.Version = outputs[2].(string)
+	}
+	if fields&domainFieldChainId != 0 {
+		domain.ChainId = (*math.HexOrDecimal256)(outputs[3].(*big.Int))
+	}
+	if fields&domainFieldVerifyingContract != 0 {
+		domain.VerifyingContract = outputs[4].(gethCommon.Address).Hex()
+	}
+	if fields&domainFieldSalt != 0 {
+		salt := outputs[5].([32]byte)
+		domain.Salt = gethCommon.Hash(salt).Hex()
+	}
+	return domain, true
+}
+
+// guessDomains returns the domains a token with name and the given version() result most
+// likely uses, ending with the domain without a version
+func (w Wallet) guessDomains(token gethCommon.Address, name string, version []any) []apitypes.TypedDataDomain {
+	var versions []string
+	if version != nil {
+		versions = append(versions, version[0].(string))
+	}
+	for _, v := range fallbackDomainVersions {
+		if version == nil || v != version[0].(string) {
+			versions = append(versions, v)
+		}
+	}
+	versions = append(versions, "")
+
+	domains := make([]apitypes.TypedDataDomain, len(versions))
+	for i, v := range versions {
+		domains[i] = apitypes.TypedDataDomain{
+			Name:              name,
+			Version:           v,
+			ChainId:           (*math.HexOrDecimal256)(new(big.Int).Set(w.chainId)),
+			VerifyingContract: token.Hex(),
+		}
+	}
+	return domains
+}
+
+// unsignableDomain explains why TokenPermit and TokenPermitDaiLike cannot sign for domain, which
+// they build from the name, the optional version, the wallet's chain and the token
+func unsignableDomain(domain apitypes.TypedDataDomain, token gethCommon.Address, chainId *big.Int) string {
+	switch {
+	case domain.Salt != "":
+		return "token's EIP-712 domain has a salt, which permit signing does not support"
+	case domain.Name == "" || domain.ChainId == nil || domain.VerifyingContract == "":
+		return "token's EIP-712 domain lacks a name, chain ID or verifying contract, which permit signing requires"
+	case (*big.Int)(domain.ChainId).Cmp(chainId) != 0:
+		return fmt.Sprintf("token's EIP-712 domain is for chain %s, the wallet is on chain %s", (*big.Int)(domain.ChainId), chainId)
+	case gethCommon.HexToAddress(domain.VerifyingContract) != token:
+		return fmt.Sprintf("token's EIP-712 domain verifies signatures for %s", domain.VerifyingContract)
+	}
+	return ""
+}
+
+// hashDomain returns the EIP-712 domain separator of domain, with the fields it sets
+func hashDomain(domain apitypes.TypedDataDomain) (gethCommon.Hash, error) {
+	values := domain.Map()
+	var fields []apitypes.Type
+	for _, field := range []apitypes.Type{
+		{Name: "name", Type: "string"},
+		{Name: "version", Type: "string"},
+		{Name: "chainId", Type: "uint256"},
+		{Name: "verifyingContract", Type: "address"},
+		{Name: "salt", Type: "bytes32"},
+	} {
+		if _, ok := values[field.Name]; ok {
+			fields = append(fields, field)
+		}
+	}
+	typedData := apitypes.TypedData{Types: apitypes.Types{"EIP712Domain": fields}, Domain: domain}
+	hash, err := typedData.HashStruct("EIP712Domain", values)
+	if err != nil {
+		return gethCommon.Hash{}, err
+	}
+	return gethCommon.BytesToHash(hash), nil
+}
@@ -0,0 +1,286 @@
+package web3_provider
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+	"github.com/1inch/1inch-sdk-go/v4/constants"
+	"github.com/1inch/1inch-sdk-go/v4/internal/web3-provider/multicall"
+)
+
+var (
+	dai = gethCommon.HexToAddress("0x6b175474e89094c44da98b954eedeac495271d0f")
+	// Mainnet DOMAIN_SEPARATOR values of USDC and DAI
+	usdcDomainSeparator = gethCommon.HexToHash("0x06c37168a7db5138defc7866392bb87a741f9b3d104deb5094588ce041cae335")
+	daiDomainSeparator  = gethCommon.HexToHash("0xdbb8cf42e1ecb028be3f3dbc922e1d878b963f411dc388ced501601c60f7c6f7")
+)
+
+// newPermitTokenNode serves the token at address through the Ethereum multicall contract.
+// methods maps a method name to its outputs; other methods revert with a reason, which the
+// multicall contract returns as the call's result.
+func newPermitTokenNode(t *testing.T, address gethCommon.Address, methods map[string][]any) *testNode {
+	erc20ABI, err := abi.JSON(strings.NewReader(constants.Erc20ABI))
+	require.NoError(t, err)
+	multicallABI, err := abi.JSON(strings.NewReader(multicall.Multicallv2abiABI))
+	require.NoError(t, err)
+	revertData := hexutil.MustDecode("0x08c379a0" + word(32) + word(3) + "6e6f7065" + strings.Repeat("0", 56))
+
+	callToken := func(to gethCommon.Address, data []byte) []byte {
+		require.Equal(t, address, to)
+		method, err := erc20ABI.MethodById(data[:4])
+		if err != nil {
+			method, err = eip712DomainABI.MethodById(data[:4])
+			require.NoError(t, err)
+		}
+		outputs, ok := methods[method.Name]
+		if !ok {
+			return revertData
+		}
+		resp, err := method.Outputs.Pack(outputs...)
+		require.NoError(t, err)
+		return resp
+	}
+
+	return newTestNode(t, map[string]rpcHandler{
+		"eth_call": func(params []json.RawMessage) (any, error) {
+			var call struct {
+				Input hexutil.Bytes `json:"input"`
+				Data  hexutil.Bytes `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(params[0], &call))
+			if call.Input == nil {
+				call.Input = call.Data
+			}
+			args, err := multicallABI.Methods["multicall"].Inputs.Unpack(call.Input[4:])
+			require.NoError(t, err)
+			calls := args[0].([]struct {
+				To   gethCommon.Address `json:"to"`
+				Data []byte             `json:"data"`
+			})
+			results := make([][]byte, len(calls))
+			for i, c := range calls {
+				results[i] = callToken(c.To, c.Data)
+			}
+			resp, err := multicallABI.Methods["multicall"].Outputs.Pack(results)
+			require.NoError(t, err)
+			return hexutil.Bytes(resp), nil
+		},
+	})
+}
+
+func TestDetectPermit(t *testing.T) {
+	nonce := big.NewInt(3)
+	noExtensions := []*big.Int{}
+	var salt [32]byte
+	salt[31] = 1
+
+	tests := []struct {
+		name                   string
+		token                  gethCommon.Address
+		methods                map[string][]any
+		expectedKind           common.PermitKind
+		expectedReason         string
+		expectedName           string
+		expectedVersion        string
+		expectedWithoutVersion bool
+		expectedFromEIP5267    bool
+	}{
+		{
+			name:  "EIP-5267 domain",
+			token: usdc,
+			methods: map[string][]any{
+				"eip712Domain":     {[1]byte{0x0f}, "USD Coin", "2", big.NewInt(1), usdc, [32]byte{}, noExtensions},
+				"DOMAIN_SEPARATOR": {[32]byte(usdcDomainSeparator)},
+				"nonces":           {nonce},
+				"name":             {"USD Coin"},
+			},
+			expectedKind:        common.PermitERC2612,
+			expectedName:        "USD Coin",
+			expectedVersion:     "2",
+			expectedFromEIP5267: true,
+		},
+		{
+			name:  "Version without getter",
+			token: usdc,
+			methods: map[string][]any{
+				"DOMAIN_SEPARATOR": {[32]byte(usdcDomainSeparator)},
+				"nonces":           {nonce},
+				"PERMIT_TYPEHASH":  {[32]byte(erc2612PermitTypeHash)},
+				"name":             {"USD Coin"},
+			},
+			expectedKind:    common.PermitERC2612,
+			expectedName:    "USD Coin",
+			expectedVersion: "2",
+		},
+		{
+			name:  "DAI-like",
+			token: dai,
+			methods: map[string][]any{
+				"DOMAIN_SEPARATOR": {[32]byte(daiDomainSeparator)},
+				"nonces":           {nonce},
+				"PERMIT_TYPEHASH":  {[32]byte(daiPermitTypeHash)},
+				"name":             {"Dai Stablecoin"},
+				"version":          {"1"},
+			},
+			expectedKind:    common.PermitDaiLike,
+			expectedName:    "Dai Stablecoin",
+			expectedVersion: "1",
+		},
+		{
+			name:  "Domain without version",
+			token: usdc,
+			methods: map[string][]any{
+				"DOMAIN_SEPARATOR": {[32]byte(mustHashDomain(t, usdc, "USD Coin", ""))},
+				"nonces":           {nonce},
+				"name":             {"USD Coin"},
+				"version":          {"1"},
+			},
+			expectedKind:           common.PermitERC2612,
+			expectedName:           "USD Coin",
+			expectedWithoutVersion: true,
+		},
+		{
+			name:  "No DOMAIN_SEPARATOR",
+			token: usdc,
+			methods: map[string][]any{
+				"nonces": {nonce},
+				"name":   {"USD Coin"},
+			},
+			expectedKind:   common.PermitUnsupported,
+			expectedReason: "token has no DOMAIN_SEPARATOR",
+		},
+		{
+			name:  "No nonces",
+			token: usdc,
+			methods: map[string][]any{
+				"DOMAIN_SEPARATOR": {[32]byte(usdcDomainSeparator)},
+				"name":             {"USD Coin"},
+			},
+			expectedKind:   common.PermitUnsupported,
+			expectedReason: "token has no nonces(address)",
+		},
+		{
+			name:  "Unknown type hash",
+			token: usdc,
+			methods: map[string][]any{
+				"DOMAIN_SEPARATOR": {[32]byte(usdcDomainSeparator)},
+				"nonces":           {nonce},
+				"PERMIT_TYPEHASH":  {[32]byte{0x01}},
+				"name":             {"USD Coin"},
+			},
+			expectedKind:   common.PermitUnsupported,
+			expectedReason: "token has an unknown PERMIT_TYPEHASH 0x0100000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name:  "Domain separator mismatch",
+			token: usdc,
+			methods: map[string][]any{
+				"DOMAIN_SEPARATOR": {[32]byte(usdcDomainSeparator)},
+				"nonces":           {nonce},
+				"name":             {"USD Coin (PoS)"},
+				"version":          {"1"},
+			},
+			expectedKind:   common.PermitUnsupported,
+			expectedReason: "no EIP-712 domain built from the token's name and version matches its DOMAIN_SEPARATOR",
+		},
+		{
+			name:  "Salted domain",
+			token: usdc,
+			methods: map[string][]any{
+				"eip712Domain":     {[1]byte{0x1b}, "USD Coin", "", big.NewInt(0), usdc, salt, noExtensions},
+				"DOMAIN_SEPARATOR": {[32]byte(mustHashDomainWithSalt(t, usdc, "USD Coin", salt))},
+				"nonces":           {nonce},
+			},
+			expectedKind:           common.PermitUnsupported,
+			expectedReason:         "token's EIP-712 domain has a salt, which permit signing does not support",
+			expectedName:           "USD Coin",
+			expectedWithoutVersion: true,
+			expectedFromEIP5267:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			node := newPermitTokenNode(t, tc.token, tc.methods)
+			w, err := DefaultWalletProvider(testPrivateKey, node.URL, constants.EthereumChainId)
+			require.NoError(t, err)
+
+			support, err := w.DetectPermit(context.Background(), tc.token)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedKind, support.Kind)
+			assert.Equal(t, tc.expectedReason, support.Reason)
+			assert.Equal(t, tc.expectedName, support.Domain.Name)
+			assert.Equal(t, tc.expectedVersion, support.Domain.Version)
+			assert.Equal(t, tc.expectedWithoutVersion, support.IsDomainWithoutVersion)
+			assert.Equal(t, tc.expectedFromEIP5267, support.FromEIP5267)
+			assert.Equal(t, tc.token, support.Token)
+			assert.Equal(t, w.Address(), support.Owner)
+		})
+	}
+}
+
+func TestDetectPermitData(t *testing.T) {
+	node := newPermitTokenNode(t, usdc, map[string][]any{
+		"DOMAIN_SEPARATOR": {[32]byte(usdcDomainSeparator)},
+		"nonces":           {big.NewInt(3)},
+		"name":             {"USD Coin"},
+		"version":          {"2"},
+	})
+	w, err := DefaultWalletProvider(testPrivateKey, node.URL, constants.EthereumChainId)
+	require.NoError(t, err)
+	support, err := w.DetectPermit(context.Background(), usdc)
+	require.NoError(t, err)
+	require.True(t, support.Supported())
+
+	cd, err := support.PermitData(spender, big.NewInt(1000), 1704250835)
+	require.NoError(t, err)
+	assert.Equal(t, &common.ContractPermitData{
+		FromToken:     usdc.Hex(),
+		Spender:       spender.Hex(),
+		Name:          "USD Coin",
+		Version:       "2",
+		PublicAddress: w.Address().Hex(),
+		ChainId:       constants.EthereumChainId,
+		Nonce:         3,
+		Deadline:      1704250835,
+		Amount:        big.NewInt(1000),
+	}, cd)
+	_, err = w.TokenPermit(*cd)
+	require.NoError(t, err)
+
+	_, err = support.PermitDataDaiLike(spender, 1704250835)
+	require.EqualError(t, err, "token "+usdc.Hex()+" does not support DAI-like permits: it uses erc2612 permits")
+}
+
+func mustHashDomain(t *testing.T, token gethCommon.Address, name string, version string) gethCommon.Hash {
+	t.Helper()
+	w := Wallet{chainId: big.NewInt(constants.EthereumChainId)}
+	for _, domain := range w.guessDomains(token, name, []any{version}) {
+		if domain.Version == version {
+			separator, err := hashDomain(domain)
+			require.NoError(t, err)
+			return separator
+		}
+	}
+	t.Fatalf("no domain with version %q", version)
+	return gethCommon.Hash{}
+}
+
+func mustHashDomainWithSalt(t *testing.T, token gethCommon.Address, name string, salt [32]byte) gethCommon.Hash {
+	t.Helper()
+	domain, ok := eip5267Domain([]any{[1]byte{0x1b}, name, "", big.NewInt(0), token, salt, []*big.Int{}})
+	require.True(t, ok)
+	separator, err := hashDomain(domain)
+	require.NoError(t, err)
+	return separator
+}
@@ -7,7 +7,6 @@ import (
 
 	gethCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 
 	"github.com/1inch/1inch-sdk-go/v4/common"
@@ -80,14 +79,9 @@ func (w Wallet) createPermitSignatureDaiLike(cd *common.ContractPermitDataDaiLik
 		Message:     orderMessage,
 	}
 
-	challengeHash, _, err := apitypes.TypedDataAndHash(typedData)
+	signature, err := w.SignTypedData(typedData)
 	if err != nil {
-		return "", fmt.Errorf("failed to compute typed data hash: %w", err)
-	}
-
-	signature, err := crypto.Sign(challengeHash, w.privateKey)
-	if err != nil {
-		return "", fmt.Errorf("failed to sign challenge hash: %w", err)
+		return "", fmt.Errorf("failed to sign permit: %w", err)
 	}
 	signature[64] += 27 // Adjust the `v` value
 
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/1inch/1inch-sdk-go/v4/common"
+	"github.com/1inch/1inch-sdk-go/v4/common/signer"
 	"github.com/1inch/1inch-sdk-go/v4/constants"
 )
 
@@ -26,11 +27,11 @@ func Test_createPermitSignatureDaiLike(t *testing.T) {
 	address := crypto.PubkeyToAddress(*publicKey.(*ecdsa.PublicKey))
 
 	w := &Wallet{
-		ethClient:  &ethclient.Client{},
-		address:    &address,
-		privateKey: privateKey,
-		chainId:    big.NewInt(int64(137)),
-		erc20ABI:   &erc20ABI,
+		ethClient: &ethclient.Client{},
+		address:   &address,
+		signer:    signer.NewPrivateKey(privateKey),
+		chainId:   big.NewInt(int64(137)),
+		erc20ABI:  &erc20ABI,
 	}
 
 	testcases := []struct {
@@ -94,11 +95,11 @@ func TestTokenPermitDaiLike(t *testing.T) {
 	address := crypto.PubkeyToAddress(*publicKey.(*ecdsa.PublicKey))
 
 	w := &Wallet{
-		ethClient:  &ethclient.Client{},
-		address:    &address,
-		privateKey: privateKey,
-		chainId:    big.NewInt(int64(137)),
-		erc20ABI:   &erc20ABI,
+		ethClient: &ethclient.Client{},
+		address:   &address,
+		signer:    signer.NewPrivateKey(privateKey),
+		chainId:   big.NewInt(int64(137)),
+		erc20ABI:  &erc20ABI,
 	}
 
 	testcases := []struct {
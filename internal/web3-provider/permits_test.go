@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/1inch/1inch-sdk-go/v4/common"
+	"github.com/1inch/1inch-sdk-go/v4/common/signer"
 	"github.com/1inch/1inch-sdk-go/v4/constants"
 )
 
@@ -28,11 +29,11 @@ func Test_createPermitSignature(t *testing.T) {
 	address := crypto.PubkeyToAddress(*publicKey.(*ecdsa.PublicKey))
 
 	w := &Wallet{
-		ethClient:  &ethclient.Client{},
-		address:    &address,
-		privateKey: privateKey,
-		chainId:    big.NewInt(int64(137)),
-		erc20ABI:   &erc20ABI,
+		ethClient: &ethclient.Client{},
+		address:   &address,
+		signer:    signer.NewPrivateKey(privateKey),
+		chainId:   big.NewInt(int64(137)),
+		erc20ABI:  &erc20ABI,
 	}
 	a := new(big.Int)
 	a, _ = a.SetString("115792089237316195423570985008687907853269984665640564039457584007913129639935", 10)
@@ -97,11 +98,11 @@ func Test_createPermitSignature2(t *testing.T) {
 	address := crypto.PubkeyToAddress(*publicKey.(*ecdsa.PublicKey))
 
 	w := &Wallet{
-		ethClient:  &ethclient.Client{},
-		address:    &address,
-		privateKey: privateKey,
-		chainId:    big.NewInt(int64(1)),
-		erc20ABI:   &erc20ABI,
+		ethClient: &ethclient.Client{},
+		address:   &address,
+		signer:    signer.NewPrivateKey(privateKey),
+		chainId:   big.NewInt(int64(1)),
+		erc20ABI:  &erc20ABI,
 	}
 
 	testcases := []struct {
@@ -164,11 +165,11 @@ func TestTokenPermit(t *testing.T) {
 	address := crypto.PubkeyToAddress(*publicKey.(*ecdsa.PublicKey))
 
 	w := &Wallet{
-		ethClient:  &ethclient.Client{},
-		address:    &address,
-		privateKey: privateKey,
-		chainId:    big.NewInt(int64(1)),
-		erc20ABI:   &erc20ABI,
+		ethClient: &ethclient.Client{},
+		address:   &address,
+		signer:    signer.NewPrivateKey(privateKey),
+		chainId:   big.NewInt(int64(1)),
+		erc20ABI:  &erc20ABI,
 	}
 
 	testcases := []struct {
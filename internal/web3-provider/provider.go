@@ -1,31 +1,43 @@
 package web3_provider
 
 import (
-	"crypto/ecdsa"
+	"context"
 	"fmt"
+	"log/slog"
 	"math/big"
+	"net/http"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
+	gethCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 
+	"github.com/1inch/1inch-sdk-go/v4/common"
+	"github.com/1inch/1inch-sdk-go/v4/common/signer"
 	"github.com/1inch/1inch-sdk-go/v4/constants"
+	"github.com/1inch/1inch-sdk-go/v4/internal/logging"
+	"github.com/1inch/1inch-sdk-go/v4/internal/rpcpool"
+	"github.com/1inch/1inch-sdk-go/v4/internal/telemetry"
 	"github.com/1inch/1inch-sdk-go/v4/internal/web3-provider/multicall"
 )
 
 type Wallet struct {
 	multicall             *multicall.Client
 	ethClient             *ethclient.Client
-	address               *common.Address
-	privateKey            *ecdsa.PrivateKey
+	address               *gethCommon.Address
+	signer                common.Signer
+	nonces                common.NonceManager
+	feeStrategy           common.FeeStrategy
 	chainId               *big.Int
 	erc20ABI              *abi.ABI
 	seriesNonceManagerABI *abi.ABI
+	telemetry             *telemetry.Instruments
+	logger                *slog.Logger
 }
 
-func DefaultWalletProvider(pk string, nodeURL string, chainId uint64) (*Wallet, error) {
+func DefaultWalletProvider(pk string, nodeURL string, chainId uint64, opts ...common.WalletOption) (*Wallet, error) {
+	cfg := common.NewWalletConfig(opts...)
 	erc20ABI, err := abi.JSON(strings.NewReader(constants.Erc20ABI))
 	if err != nil {
 		return nil, err
@@ -34,17 +46,16 @@ func DefaultWalletProvider(pk string, nodeURL string, chainId uint64) (*Wallet,
 	if err != nil {
 		return nil, err
 	}
-	privateKey, err := crypto.HexToECDSA(pk)
+	s, err := newSigner(pk, cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize private key: %w", err)
+		return nil, err
 	}
-	ethClient, err := ethclient.Dial(nodeURL)
+	ethClient, err := dialNode(nodeURL, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create eth client: %w", err)
 	}
 
-	publicKey := privateKey.Public()
-	address := crypto.PubkeyToAddress(*publicKey.(*ecdsa.PublicKey))
+	address := s.Address()
 
 	m, err := multicall.NewMulticall(ethClient, chainId)
 	if err != nil {
@@ -55,25 +66,61 @@ func DefaultWalletProvider(pk string, nodeURL string, chainId uint64) (*Wallet,
 		multicall:             m,
 		ethClient:             ethClient,
 		address:               &address,
-		privateKey:            privateKey,
+		signer:                s,
+		nonces:                cfg.NonceManager,
+		feeStrategy:           cfg.FeeStrategy,
 		chainId:               big.NewInt(int64(chainId)),
 		erc20ABI:              &erc20ABI,
 		seriesNonceManagerABI: &seriesNonceManagerABI,
+		telemetry:             telemetry.New(cfg.Telemetry),
+		logger:                logging.New(cfg.Logger),
 	}, nil
 }
 
-func DefaultWalletOnlyProvider(pk string, chainId uint64) (*Wallet, error) {
-	privateKey, err := crypto.HexToECDSA(pk)
+func DefaultWalletOnlyProvider(pk string, chainId uint64, opts ...common.WalletOption) (*Wallet, error) {
+	cfg := common.NewWalletConfig(opts...)
+	s, err := newSigner(pk, cfg)
 	if err != nil {
 		return nil, err
 	}
-
-	publicKey := privateKey.Public()
-	address := crypto.PubkeyToAddress(*publicKey.(*ecdsa.PublicKey))
+	address := s.Address()
 
 	return &Wallet{
-		address:    &address,
-		privateKey: privateKey,
-		chainId:    big.NewInt(int64(chainId)),
+		address:     &address,
+		signer:      s,
+		nonces:      cfg.NonceManager,
+		feeStrategy: cfg.FeeStrategy,
+		chainId:     big.NewInt(int64(chainId)),
+		telemetry:   telemetry.New(cfg.Telemetry),
+		logger:      logging.New(cfg.Logger),
 	}, nil
 }
+
+// newSigner returns the signer set with common.WithSigner, or an in-memory signer for pk
+// when there is none. Setting both is an error, so a key is never silently ignored.
+func newSigner(pk string, cfg common.WalletConfig) (common.Signer, error) {
+	if cfg.Signer != nil {
+		if pk != "" {
+			return nil, fmt.Errorf("a private key and a signer cannot both be set")
+		}
+		return cfg.Signer, nil
+	}
+	return signer.NewPrivateKeyFromHex(pk)
+}
+
+// dialNode connects to nodeURL. When common.WithNodeURLs adds endpoints, requests go through
+// a pool that fails over between them, broadcasts to all of them and reads with a quorum.
+func dialNode(nodeURL string, cfg common.WalletConfig) (*ethclient.Client, error) {
+	if len(cfg.NodeURLs) == 0 {
+		return ethclient.Dial(nodeURL)
+	}
+	pool, err := rpcpool.New(append([]string{nodeURL}, cfg.NodeURLs...), cfg.NodePolicy, nil)
+	if err != nil {
+		return nil, err
+	}
+	client, err := rpc.DialOptions(context.Background(), nodeURL, rpc.WithHTTPClient(&http.Client{Transport: pool}))
+	if err != nil {
+		return nil, err
+	}
+	return ethclient.NewClient(client), nil
+}
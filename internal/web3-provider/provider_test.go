@@ -1,9 +1,20 @@
 package web3_provider
 
 import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+	"github.com/1inch/1inch-sdk-go/v4/common/signer"
+	"github.com/1inch/1inch-sdk-go/v4/constants"
 )
 
 func TestDefaultWalletProvider_FailureCases(t *testing.T) {
@@ -12,6 +23,7 @@ func TestDefaultWalletProvider_FailureCases(t *testing.T) {
 		privateKey  string
 		nodeURL     string
 		chainID     uint64
+		opts        []common.WalletOption
 		expectError string
 	}{
 		{
@@ -35,13 +47,80 @@ func TestDefaultWalletProvider_FailureCases(t *testing.T) {
 			chainID:     1,
 			expectError: "failed to create eth client",
 		},
+		{
+			description: "WebSocket node URL with extra node URLs",
+			privateKey:  "85cc05822dc41dbd5253767374b12ca1d08d4d347af2c0bf7bbff8edc3dfa950",
+			nodeURL:     "wss://mainnet.infura.io/ws/v3/randomProjectId",
+			chainID:     1,
+			opts:        []common.WalletOption{common.WithNodeURLs("https://eth.llamarpc.com")},
+			expectError: "only http and https endpoints can be combined",
+		},
 	}
 
 	for _, tc := range testcases {
 		t.Run(tc.description, func(t *testing.T) {
-			_, err := DefaultWalletProvider(tc.privateKey, tc.nodeURL, tc.chainID)
+			_, err := DefaultWalletProvider(tc.privateKey, tc.nodeURL, tc.chainID, tc.opts...)
 			require.Error(t, err)
 			require.Contains(t, err.Error(), tc.expectError)
 		})
 	}
 }
+
+func TestDefaultWalletOnlyProvider_Signer(t *testing.T) {
+	s, err := signer.NewPrivateKeyFromHex("965e092fdfc08940d2bd05c7b5c7e1c51e283e92c7f52bbf1408973ae9a9acb7")
+	require.NoError(t, err)
+
+	w, err := DefaultWalletOnlyProvider("", 1, common.WithSigner(s))
+	require.NoError(t, err)
+	require.Equal(t, s.Address(), w.Address())
+
+	hash := crypto.Keccak256([]byte("message"))
+	signature, err := w.SignBytes(hash)
+	require.NoError(t, err)
+	expected, err := s.SignHash(context.Background(), hash)
+	require.NoError(t, err)
+	require.Equal(t, expected, signature)
+
+	to := w.Address()
+	signedTx, err := w.Sign(types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), Gas: 21000, GasFeeCap: big.NewInt(1), GasTipCap: big.NewInt(1), To: &to}))
+	require.NoError(t, err)
+	sender, err := types.Sender(types.LatestSignerForChainID(big.NewInt(1)), signedTx)
+	require.NoError(t, err)
+	require.Equal(t, s.Address(), sender)
+
+	_, err = DefaultWalletOnlyProvider("965e092fdfc08940d2bd05c7b5c7e1c51e283e92c7f52bbf1408973ae9a9acb7", 1, common.WithSigner(s))
+	require.EqualError(t, err, "a private key and a signer cannot both be set")
+}
+
+func TestDefaultWalletProvider_NodeURLs(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	t.Cleanup(down.Close)
+	handlers := map[string]rpcHandler{
+		"eth_getTransactionCount": func(params []json.RawMessage) (any, error) { return "0x7", nil },
+		"eth_sendRawTransaction": func(params []json.RawMessage) (any, error) {
+			return "0x0000000000000000000000000000000000000000000000000000000000000001", nil
+		},
+	}
+	second, third := newTestNode(t, handlers), newTestNode(t, handlers)
+
+	w, err := DefaultWalletProvider(testPrivateKey, down.URL, constants.EthereumChainId,
+		common.WithNodeURLs(second.URL, third.URL),
+		common.WithNodePolicy(common.NodePolicy{Quorum: 2}))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	nonce, err := w.Nonce(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), nonce)
+
+	to := w.Address()
+	tx, err := w.Sign(types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), Nonce: nonce, Gas: 21000, GasFeeCap: big.NewInt(1), GasTipCap: big.NewInt(1), To: &to}))
+	require.NoError(t, err)
+	require.NoError(t, w.BroadcastTransaction(ctx, tx))
+
+	for _, node := range []*testNode{second, third} {
+		require.Equal(t, []string{"eth_getTransactionCount", "eth_sendRawTransaction"}, node.methodCalls())
+	}
+}
@@ -0,0 +1,85 @@
+package web3_provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+	"github.com/1inch/1inch-sdk-go/v4/constants"
+)
+
+const testPrivateKey = "965e092fdfc08940d2bd05c7b5c7e1c51e283e92c7f52bbf1408973ae9a9acb7"
+
+func TestWalletTelemetry(t *testing.T) {
+	tests := []struct {
+		name           string
+		call           func(ctx context.Context, w *Wallet) error
+		expectedSpan   string
+		expectedStatus codes.Code
+	}{
+		{
+			name: "Nonce",
+			call: func(ctx context.Context, w *Wallet) error {
+				_, err := w.Nonce(ctx)
+				return err
+			},
+			expectedSpan:   "eth_getTransactionCount",
+			expectedStatus: codes.Unset,
+		},
+		{
+			name: "Call",
+			call: func(ctx context.Context, w *Wallet) error {
+				_, err := w.Call(ctx, gethCommon.HexToAddress("0x1111111254eeb25477b68fb85ed929f73a960582"), []byte{0x01})
+				return err
+			},
+			expectedSpan:   "eth_call",
+			expectedStatus: codes.Unset,
+		},
+		{
+			name: "Gas price error",
+			call: func(ctx context.Context, w *Wallet) error {
+				_, err := w.GetGasPrice(ctx)
+				return err
+			},
+			expectedSpan:   "eth_gasPrice",
+			expectedStatus: codes.Error,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			node := newTestNode(t, map[string]rpcHandler{
+				"eth_getTransactionCount": func(params []json.RawMessage) (any, error) { return "0x5", nil },
+				"eth_call":                func(params []json.RawMessage) (any, error) { return "0x", nil },
+				"eth_gasPrice":            func(params []json.RawMessage) (any, error) { return nil, errors.New("node unavailable") },
+			})
+			recorder := tracetest.NewSpanRecorder()
+			w, err := DefaultWalletProvider(testPrivateKey, node.URL, constants.EthereumChainId,
+				common.WithWalletTelemetry(common.Telemetry{
+					TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)),
+				}))
+			require.NoError(t, err)
+
+			callErr := tc.call(context.Background(), w)
+			if tc.expectedStatus == codes.Error {
+				require.Error(t, callErr)
+			} else {
+				require.NoError(t, callErr)
+			}
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, tc.expectedSpan, spans[0].Name())
+			assert.Equal(t, tc.expectedStatus, spans[0].Status().Code)
+		})
+	}
+}
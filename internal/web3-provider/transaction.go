@@ -2,16 +2,22 @@ package web3_provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/1inch/1inch-sdk-go/v4/internal/logging"
 )
 
 func (w Wallet) Sign(tx *types.Transaction) (*types.Transaction, error) {
-	signer := types.LatestSignerForChainID(w.chainId)
-	signedTx, err := types.SignTx(tx, signer, w.privateKey)
+	signedTx, err := w.signer.SignTransaction(context.Background(), tx, w.chainId)
 	if err != nil {
 		return nil, err
 	}
@@ -19,21 +25,114 @@ func (w Wallet) Sign(tx *types.Transaction) (*types.Transaction, error) {
 }
 
 func (w Wallet) SignBytes(bytes []byte) ([]byte, error) {
-	signature, err := crypto.Sign(bytes, w.privateKey)
+	signature, err := w.signer.SignHash(context.Background(), bytes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign bytes: %w", err)
 	}
 	return signature, nil
 }
 
+// SignTypedData signs the EIP-712 digest of typedData. The signer receives the structured
+// data, so remote signers can show the user what they are signing.
+func (w Wallet) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	signature, err := w.signer.SignTypedData(context.Background(), typedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign typed data: %w", err)
+	}
+	return signature, nil
+}
+
+// SignAuthorization signs the EIP-7702 digest of auth with the wallet's signer, which must be
+// able to sign raw hashes.
+func (w Wallet) SignAuthorization(ctx context.Context, auth types.SetCodeAuthorization) (types.SetCodeAuthorization, error) {
+	hash := auth.SigHash()
+	signature, err := w.signer.SignHash(ctx, hash[:])
+	if err != nil {
+		return types.SetCodeAuthorization{}, fmt.Errorf("failed to sign authorization: %w", err)
+	}
+	if len(signature) != crypto.SignatureLength {
+		return types.SetCodeAuthorization{}, fmt.Errorf("failed to sign authorization: got a %d-byte signature", len(signature))
+	}
+	auth.R.SetBytes(signature[:32])
+	auth.S.SetBytes(signature[32:64])
+	auth.V = signature[crypto.RecoveryIDOffset]
+	return auth, nil
+}
+
 func (w Wallet) BroadcastTransaction(ctx context.Context, tx *types.Transaction) error {
-	err := w.ethClient.SendTransaction(ctx, tx)
+	return w.broadcast(ctx, tx, true)
+}
+
+// BroadcastReplacement broadcasts a speed-up or cancellation of a sent transaction. The nonce
+// manager already counts the nonce as sent, and a "nonce too low" answer only means that
+// another transaction with the nonce was mined, so the outcome is not reported to it.
+func (w Wallet) BroadcastReplacement(ctx context.Context, tx *types.Transaction) error {
+	return w.broadcast(ctx, tx, false)
+}
+
+func (w Wallet) broadcast(ctx context.Context, tx *types.Transaction, trackNonce bool) (err error) {
+	ctx, call := w.telemetry.StartRPC(ctx, "eth_sendRawTransaction", w.ChainId())
+	defer func() { call.End(err) }()
+
+	err = w.ethClient.SendTransaction(ctx, tx)
+	if trackNonce {
+		w.trackNonce(tx.Nonce(), err)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to broadcast transaction: %w", err)
 	}
+	logging.Debug(ctx, w.logger, "transaction broadcast",
+		slog.String("txHash", tx.Hash().Hex()),
+		slog.Uint64("nonce", tx.Nonce()),
+		slog.Int64("chainId", w.ChainId()))
 	return nil
 }
 
-func (w Wallet) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+func (w Wallet) TransactionReceipt(ctx context.Context, txHash common.Hash) (receipt *types.Receipt, err error) {
+	ctx, call := w.telemetry.StartRPC(ctx, "eth_getTransactionReceipt", w.ChainId())
+	defer func() { call.End(err) }()
+
 	return w.ethClient.TransactionReceipt(ctx, txHash)
 }
+
+func (w Wallet) BlockNumber(ctx context.Context) (number uint64, err error) {
+	ctx, call := w.telemetry.StartRPC(ctx, "eth_blockNumber", w.ChainId())
+	defer func() { call.End(err) }()
+
+	if w.ethClient == nil {
+		return 0, fmt.Errorf("wallet has no node connection: create it with a node URL to read the block number")
+	}
+	return w.ethClient.BlockNumber(ctx)
+}
+
+// trackNonce reports the outcome of a broadcast to the nonce manager. A nonce the node
+// already knows means the local count is behind, so the manager resyncs; a node that rejects
+// the transaction leaves the nonce unused, so it is released for the next transaction. Any
+// other failure, such as a timeout or a dropped connection, may come after the node accepted
+// the transaction, so the nonce stays reserved rather than being handed out twice.
+func (w Wallet) trackNonce(nonce uint64, err error) {
+	if w.nonces == nil {
+		return
+	}
+	var rejected rpc.Error
+	switch {
+	case err == nil:
+		w.nonces.Sent(nonce)
+	case isNonceConflict(err):
+		w.nonces.Resync()
+	case errors.As(err, &rejected):
+		w.nonces.Release(nonce)
+	}
+}
+
+// isNonceConflict reports whether a broadcast failed because the nonce is already used.
+// Nodes return these as JSON-RPC error messages, which differ slightly between clients.
+func isNonceConflict(err error) bool {
+	message := strings.ToLower(err.Error())
+	for _, conflict := range []string{"nonce too low", "already known", "known transaction"} {
+		if strings.Contains(message, conflict) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+package web3_provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+	"github.com/1inch/1inch-sdk-go/v4/common/nonce"
+	"github.com/1inch/1inch-sdk-go/v4/common/signer"
+	"github.com/1inch/1inch-sdk-go/v4/constants"
+)
+
+func TestBroadcastTransaction_NonceManager(t *testing.T) {
+	tests := []struct {
+		name          string
+		broadcastErr  error
+		timeout       bool
+		replacement   bool
+		expectedNext  uint64
+		expectedSyncs int
+	}{
+		{
+			name:          "Sent nonces are not reused",
+			expectedNext:  6,
+			expectedSyncs: 1,
+		},
+		{
+			name:          "Nonce too low resyncs",
+			broadcastErr:  errors.New("nonce too low: next nonce 9, tx nonce 5"),
+			expectedNext:  5,
+			expectedSyncs: 2,
+		},
+		{
+			name:          "Already known resyncs",
+			broadcastErr:  errors.New("already known"),
+			expectedNext:  5,
+			expectedSyncs: 2,
+		},
+		{
+			name:          "Node rejections release the nonce",
+			broadcastErr:  errors.New("insufficient funds for gas * price + value"),
+			expectedNext:  5,
+			expectedSyncs: 1,
+		},
+		{
+			name:          "Replacements skip the nonce manager",
+			broadcastErr:  errors.New("nonce too low: next nonce 9, tx nonce 5"),
+			replacement:   true,
+			expectedNext:  6,
+			expectedSyncs: 1,
+		},
+		{
+			name:          "Timeouts keep the nonce reserved",
+			timeout:       true,
+			expectedNext:  6,
+			expectedSyncs: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// The node accepts the transaction but answers only after the broadcast timed out
+			accepted := make(chan struct{})
+			node := newTestNode(t, map[string]rpcHandler{
+				"eth_getTransactionCount": func(params []json.RawMessage) (any, error) {
+					var block string
+					require.NoError(t, json.Unmarshal(params[1], &block))
+					assert.Equal(t, "pending", block)
+					return "0x5", nil
+				},
+				"eth_sendRawTransaction": func(params []json.RawMessage) (any, error) {
+					if tc.broadcastErr != nil {
+						return nil, tc.broadcastErr
+					}
+					if tc.timeout {
+						<-accepted
+					}
+					return "0x0000000000000000000000000000000000000000000000000000000000000001", nil
+				},
+			})
+			t.Cleanup(func() { close(accepted) })
+			w, err := DefaultWalletProvider(testPrivateKey, node.URL, constants.EthereumChainId, common.WithNonceManager(nonce.NewManager()))
+			require.NoError(t, err)
+			ctx := context.Background()
+
+			n, err := w.Nonce(ctx)
+			require.NoError(t, err)
+			require.Equal(t, uint64(5), n)
+			to := w.Address()
+			tx, err := w.Sign(types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), Nonce: n, Gas: 21000, GasFeeCap: big.NewInt(1), GasTipCap: big.NewInt(1), To: &to}))
+			require.NoError(t, err)
+
+			broadcastCtx := ctx
+			if tc.timeout {
+				var cancel context.CancelFunc
+				broadcastCtx, cancel = context.WithTimeout(ctx, 50*time.Millisecond)
+				defer cancel()
+			}
+			if tc.replacement {
+				err = w.BroadcastReplacement(broadcastCtx, tx)
+			} else {
+				err = w.BroadcastTransaction(broadcastCtx, tx)
+			}
+			if tc.timeout {
+				require.ErrorIs(t, err, context.DeadlineExceeded)
+			} else if tc.broadcastErr != nil {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			next, err := w.Nonce(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedNext, next)
+
+			syncs := 0
+			for _, method := range node.methodCalls() {
+				if method == "eth_getTransactionCount" {
+					syncs++
+				}
+			}
+			assert.Equal(t, tc.expectedSyncs, syncs)
+		})
+	}
+}
+
+// hashlessSigner cannot sign raw hashes, like a remote signer
+type hashlessSigner struct {
+	common.Signer
+}
+
+func (hashlessSigner) SignHash(context.Context, []byte) ([]byte, error) {
+	return nil, errors.ErrUnsupported
+}
+
+func TestSignAuthorization(t *testing.T) {
+	delegate := gethCommon.HexToAddress("0x63c0c19a282a1b52b07dd5a65b58948a07dae32b")
+	node := newTestNode(t, nil)
+
+	w, err := DefaultWalletProvider(testPrivateKey, node.URL, constants.EthereumChainId)
+	require.NoError(t, err)
+	auth, err := w.SignAuthorization(context.Background(), types.SetCodeAuthorization{
+		ChainID: *uint256.NewInt(1),
+		Address: delegate,
+		Nonce:   7,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, delegate, auth.Address)
+	assert.Equal(t, uint64(7), auth.Nonce)
+	authority, err := auth.Authority()
+	require.NoError(t, err)
+	assert.Equal(t, w.Address(), authority)
+
+	key, err := signer.NewPrivateKeyFromHex(testPrivateKey)
+	require.NoError(t, err)
+	w, err = DefaultWalletProvider("", node.URL, constants.EthereumChainId, common.WithSigner(hashlessSigner{Signer: key}))
+	require.NoError(t, err)
+	_, err = w.SignAuthorization(context.Background(), types.SetCodeAuthorization{Address: delegate})
+	require.ErrorIs(t, err, errors.ErrUnsupported)
+}
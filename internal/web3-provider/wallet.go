@@ -8,20 +8,47 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 )
 
-func (w Wallet) Nonce(ctx context.Context) (uint64, error) {
-	nonce, err := w.ethClient.NonceAt(ctx, *w.address, nil)
+// Nonce returns the nonce for the next transaction. With a nonce manager the nonce is
+// reserved for the caller; without one it is the account's nonce at the latest block.
+func (w Wallet) Nonce(ctx context.Context) (nonce uint64, err error) {
+	if w.nonces != nil {
+		return w.nonces.Next(ctx, w.pendingNonce)
+	}
+
+	ctx, call := w.telemetry.StartRPC(ctx, "eth_getTransactionCount", w.ChainId())
+	defer func() { call.End(err) }()
+
+	nonce, err = w.ethClient.NonceAt(ctx, *w.address, nil)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get nonce: %w", err)
 	}
 	return nonce, nil
 }
 
+// pendingNonce returns the account's nonce including the transactions in the mempool
+func (w Wallet) pendingNonce(ctx context.Context) (nonce uint64, err error) {
+	ctx, call := w.telemetry.StartRPC(ctx, "eth_getTransactionCount", w.ChainId())
+	defer func() { call.End(err) }()
+
+	if w.ethClient == nil {
+		return 0, fmt.Errorf("wallet has no node connection: create it with a node URL to read the nonce")
+	}
+	nonce, err = w.ethClient.PendingNonceAt(ctx, *w.address)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get pending nonce: %w", err)
+	}
+	return nonce, nil
+}
+
 func (w Wallet) Address() common.Address {
 	return *w.address
 }
 
-func (w Wallet) Balance(ctx context.Context) (*big.Int, error) {
-	balance, err := w.ethClient.BalanceAt(ctx, *w.address, nil)
+func (w Wallet) Balance(ctx context.Context) (balance *big.Int, err error) {
+	ctx, call := w.telemetry.StartRPC(ctx, "eth_getBalance", w.ChainId())
+	defer func() { call.End(err) }()
+
+	balance, err = w.ethClient.BalanceAt(ctx, *w.address, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve balance: %w", err)
 	}
@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum"
 	gethCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/1inch/1inch-sdk-go/v4/common"
@@ -154,10 +155,22 @@ func (w *MyWallet) SignBytes(data []byte) ([]byte, error) {
 	return nil, nil
 }
 
+func (w *MyWallet) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	return nil, nil
+}
+
+func (w *MyWallet) SignAuthorization(ctx context.Context, auth types.SetCodeAuthorization) (types.SetCodeAuthorization, error) {
+	return auth, nil
+}
+
 func (w *MyWallet) BroadcastTransaction(ctx context.Context, tx *types.Transaction) error {
 	return nil
 }
 
+func (w *MyWallet) BroadcastReplacement(ctx context.Context, tx *types.Transaction) error {
+	return nil
+}
+
 func (w *MyWallet) EstimateGas(ctx context.Context, contractAddress gethCommon.Address, callData []byte) (uint64, error) {
 	return 0, nil
 }
@@ -166,6 +179,50 @@ func (w *MyWallet) TransactionReceipt(ctx context.Context, txHash gethCommon.Has
 	return nil, nil
 }
 
+func (w *MyWallet) BlockNumber(ctx context.Context) (uint64, error) {
+	return 0, nil
+}
+
+func (w *MyWallet) Simulate(ctx context.Context, msg ethereum.CallMsg) ([]byte, error) {
+	return nil, nil
+}
+
+func (w *MyWallet) CreateAccessList(ctx context.Context, msg ethereum.CallMsg) (types.AccessList, error) {
+	return nil, nil
+}
+
+func (w *MyWallet) TokenBalance(ctx context.Context, token gethCommon.Address) (*big.Int, error) {
+	return nil, nil
+}
+
+func (w *MyWallet) TokenBalances(ctx context.Context, tokens []gethCommon.Address) ([]*big.Int, error) {
+	return nil, nil
+}
+
+func (w *MyWallet) TokenAllowance(ctx context.Context, token gethCommon.Address, spender gethCommon.Address) (*big.Int, error) {
+	return nil, nil
+}
+
+func (w *MyWallet) TokenAllowances(ctx context.Context, tokens []gethCommon.Address, spender gethCommon.Address) ([]*big.Int, error) {
+	return nil, nil
+}
+
+func (w *MyWallet) TokenDecimals(ctx context.Context, token gethCommon.Address) (uint8, error) {
+	return 0, nil
+}
+
+func (w *MyWallet) TokenSymbol(ctx context.Context, token gethCommon.Address) (string, error) {
+	return "", nil
+}
+
+func (w *MyWallet) TokenApprove(ctx context.Context, token gethCommon.Address, spender gethCommon.Address, amount *big.Int) (*types.Transaction, error) {
+	return nil, nil
+}
+
+func (w *MyWallet) TokenTransfer(ctx context.Context, token gethCommon.Address, to gethCommon.Address, amount *big.Int) (*types.Transaction, error) {
+	return nil, nil
+}
+
 func (w *MyWallet) GetContractDetailsForPermitDaiLike(ctx context.Context, token gethCommon.Address, spender gethCommon.Address, deadline int64) (*common.ContractPermitDataDaiLike, error) {
 	return nil, nil
 }
@@ -174,6 +231,10 @@ func (w *MyWallet) TokenPermitDaiLike(cd common.ContractPermitDataDaiLike) (stri
 	return "", nil
 }
 
+func (w *MyWallet) DetectPermit(ctx context.Context, token gethCommon.Address) (*common.PermitSupport, error) {
+	return nil, nil
+}
+
 func (w *MyWallet) TokenPermit(cd common.ContractPermitData) (string, error) {
 	return "", nil
 }
@@ -28,19 +28,21 @@ type ConfigurationWallet struct {
 }
 
 type ConfigurationParams struct {
-	NodeUrl    string
-	PrivateKey string
-	ChainId    uint64
-	ApiUrl     string
-	ApiKey     string
+	NodeUrl       string
+	PrivateKey    string
+	ChainId       uint64
+	ApiUrl        string
+	ApiKey        string
+	HttpOptions   []common.HttpOption
+	WalletOptions []common.WalletOption
 }
 
 func NewConfiguration(params ConfigurationParams) (*Configuration, error) {
-	apiCfg, err := NewConfigurationAPI(params.ChainId, params.ApiUrl, params.ApiKey)
+	apiCfg, err := NewConfigurationAPI(params.ChainId, params.ApiUrl, params.ApiKey, params.HttpOptions...)
 	if err != nil {
 		return nil, err
 	}
-	walletCfg, err := NewConfigurationWallet(params.NodeUrl, params.PrivateKey, params.ChainId)
+	walletCfg, err := NewConfigurationWallet(params.NodeUrl, params.PrivateKey, params.ChainId, params.WalletOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -50,8 +52,8 @@ func NewConfiguration(params ConfigurationParams) (*Configuration, error) {
 	}, nil
 }
 
-func NewConfigurationAPI(chainId uint64, apiUrl string, apiKey string) (*ConfigurationAPI, error) {
-	executor, err := http_executor.DefaultHttpClient(apiUrl, apiKey)
+func NewConfigurationAPI(chainId uint64, apiUrl string, apiKey string, opts ...common.HttpOption) (*ConfigurationAPI, error) {
+	executor, err := http_executor.NewExecutor(apiUrl, apiKey, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -69,13 +71,13 @@ func NewConfigurationAPI(chainId uint64, apiUrl string, apiKey string) (*Configu
 	}, nil
 }
 
-func NewConfigurationWallet(nodeUrl string, privateKey string, chainId uint64) (*ConfigurationWallet, error) {
-	w, err := web3_provider.DefaultWalletProvider(privateKey, nodeUrl, chainId)
+func NewConfigurationWallet(nodeUrl string, privateKey string, chainId uint64, opts ...common.WalletOption) (*ConfigurationWallet, error) {
+	w, err := web3_provider.DefaultWalletProvider(privateKey, nodeUrl, chainId, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	f := transaction_builder.NewFactory(w)
+	f := transaction_builder.NewFactory(w, opts...)
 	return &ConfigurationWallet{
 		Wallet:    w,
 		TxBuilder: f,
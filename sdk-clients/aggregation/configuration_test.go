@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/1inch/1inch-sdk-go/v4/common"
 	"github.com/1inch/1inch-sdk-go/v4/constants"
 )
 
@@ -67,3 +68,12 @@ func TestNewConfigurationWallet(t *testing.T) {
 		})
 	}
 }
+
+func TestNewConfigurationAPIWithExecutor(t *testing.T) {
+	executor := &MockHttpExecutor{}
+
+	configAPI, err := NewConfigurationAPI(1, "https://api.example.com", "apikey123", common.WithExecutor(executor))
+
+	assert.NoError(t, err)
+	assert.Same(t, executor, configAPI.API.httpExecutor)
+}
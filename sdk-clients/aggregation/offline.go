@@ -0,0 +1,65 @@
+package aggregation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+	"github.com/1inch/1inch-sdk-go/v4/common/offline"
+)
+
+// BuildUnsignedSwap builds the transaction of swap, with nonce, fees and gas resolved by the
+// client's wallet, for signing on an offline machine. The gas limit of the swap response is
+// used when the API returned one.
+func (c *Client) BuildUnsignedSwap(ctx context.Context, swap *SwapResponseExtended) (*offline.UnsignedTransaction, error) {
+	if swap == nil {
+		return nil, errors.New("swap response is required")
+	}
+	builder, err := c.unsignedBuilder()
+	if err != nil {
+		return nil, err
+	}
+	builder = builder.SetData(swap.TxNormalized.Data).SetTo(&swap.TxNormalized.To).SetValue(swap.TxNormalized.Value)
+	if swap.TxNormalized.Gas != 0 {
+		builder = builder.SetGas(swap.TxNormalized.Gas)
+	}
+	return c.buildUnsigned(ctx, builder, offline.KindSwap, describeSwap(swap))
+}
+
+// BuildUnsignedApprove builds the transaction of approve, with nonce, fees and gas resolved by
+// the client's wallet, for signing on an offline machine.
+func (c *Client) BuildUnsignedApprove(ctx context.Context, approve *ApproveCallDataResponseExtended) (*offline.UnsignedTransaction, error) {
+	if approve == nil {
+		return nil, errors.New("approve response is required")
+	}
+	builder, err := c.unsignedBuilder()
+	if err != nil {
+		return nil, err
+	}
+	builder = builder.SetData(approve.TxNormalized.Data).SetTo(&approve.TxNormalized.To).SetValue(approve.TxNormalized.Value)
+	description := fmt.Sprintf("approve the 1inch router to spend token %s", approve.TxNormalized.To.Hex())
+	return c.buildUnsigned(ctx, builder, offline.KindApprove, description)
+}
+
+func (c *Client) unsignedBuilder() (common.TransactionBuilder, error) {
+	if c.Wallet == nil || c.TxBuilder == nil {
+		return nil, errors.New("a wallet configuration is required to build transactions")
+	}
+	return c.TxBuilder.New(), nil
+}
+
+func (c *Client) buildUnsigned(ctx context.Context, builder common.TransactionBuilder, kind string, description string) (*offline.UnsignedTransaction, error) {
+	tx, err := builder.Build(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build %s transaction: %w", kind, err)
+	}
+	return offline.NewUnsignedTransaction(tx, c.Wallet.Address(), uint64(c.Wallet.ChainId()), kind, description)
+}
+
+func describeSwap(swap *SwapResponseExtended) string {
+	if swap.SrcToken == nil || swap.DstToken == nil {
+		return fmt.Sprintf("swap for an expected %s base units of the destination token", swap.DstAmount)
+	}
+	return fmt.Sprintf("swap %s for an expected %s base units of %s", swap.SrcToken.Symbol, swap.DstAmount, swap.DstToken.Symbol)
+}
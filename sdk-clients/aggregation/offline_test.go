@@ -0,0 +1,71 @@
+package aggregation
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/v4/common/offline"
+	"github.com/1inch/1inch-sdk-go/v4/constants"
+	transaction_builder "github.com/1inch/1inch-sdk-go/v4/internal/transaction-builder"
+)
+
+func TestBuildUnsigned(t *testing.T) {
+	from := gethCommon.HexToAddress("0x2c9b2dbdba8a9c969ac24153f5c1c23cb0e63914")
+	router := gethCommon.HexToAddress(constants.AggregationRouterV6)
+	token := gethCommon.HexToAddress("0x5a98fcbea516cf06857215779fd812ca3bef1b32")
+
+	mockWallet := NewMyWallet(from, big.NewInt(constants.EthereumChainId))
+	client := &Client{
+		Wallet:    mockWallet,
+		TxBuilder: transaction_builder.NewFactory(mockWallet),
+	}
+
+	t.Run("Swap", func(t *testing.T) {
+		swap := &SwapResponseExtended{
+			SwapResponse: mockedSwapHttpApiResp,
+			TxNormalized: NormalizedTransactionData{Data: []byte{0x12, 0xaa}, Gas: 250_000, To: router, Value: big.NewInt(5)},
+		}
+		unsigned, err := client.BuildUnsignedSwap(context.Background(), swap)
+		require.NoError(t, err)
+
+		assert.Equal(t, offline.KindSwap, unsigned.Metadata.Kind)
+		assert.Equal(t, "swap LDO for an expected "+mockedSwapHttpApiResp.DstAmount+" base units of WETH", unsigned.Metadata.Description)
+		assert.Equal(t, from, unsigned.Metadata.From)
+		assert.Equal(t, uint64(constants.EthereumChainId), unsigned.Metadata.ChainId)
+		assert.Equal(t, &router, unsigned.Tx.To())
+		assert.Equal(t, uint64(44), unsigned.Tx.Nonce())
+		assert.Equal(t, uint64(250_000), unsigned.Tx.Gas())
+		assert.Equal(t, big.NewInt(5), unsigned.Tx.Value())
+	})
+
+	t.Run("Swap without API gas", func(t *testing.T) {
+		swap := &SwapResponseExtended{
+			SwapResponse: mockedSwapHttpApiResp,
+			TxNormalized: NormalizedTransactionData{Data: []byte{0x12, 0xaa}, To: router, Value: big.NewInt(0)},
+		}
+		unsigned, err := client.BuildUnsignedSwap(context.Background(), swap)
+		require.NoError(t, err)
+		assert.NotZero(t, unsigned.Tx.Gas())
+	})
+
+	t.Run("Approve", func(t *testing.T) {
+		approve := &ApproveCallDataResponseExtended{
+			TxNormalized: NormalizedTransactionData{Data: []byte{0x09, 0x5e, 0xa7, 0xb3}, To: token, Value: big.NewInt(0)},
+		}
+		unsigned, err := client.BuildUnsignedApprove(context.Background(), approve)
+		require.NoError(t, err)
+		assert.Equal(t, offline.KindApprove, unsigned.Metadata.Kind)
+		assert.Equal(t, &token, unsigned.Tx.To())
+		assert.Contains(t, unsigned.Metadata.Description, token.Hex())
+	})
+
+	t.Run("No wallet", func(t *testing.T) {
+		_, err := (&Client{}).BuildUnsignedSwap(context.Background(), &SwapResponseExtended{})
+		require.EqualError(t, err, "a wallet configuration is required to build transactions")
+	})
+}
@@ -1,6 +1,7 @@
 package balances
 
 import (
+	"github.com/1inch/1inch-sdk-go/v4/common"
 	http_executor "github.com/1inch/1inch-sdk-go/v4/internal/http-executor"
 )
 
@@ -11,13 +12,14 @@ type Configuration struct {
 }
 
 type ConfigurationParams struct {
-	ChainId uint64
-	ApiUrl  string
-	ApiKey  string
+	ChainId     uint64
+	ApiUrl      string
+	ApiKey      string
+	HttpOptions []common.HttpOption
 }
 
 func NewConfiguration(params ConfigurationParams) (*Configuration, error) {
-	executor, err := http_executor.DefaultHttpClient(params.ApiUrl, params.ApiKey)
+	executor, err := http_executor.NewExecutor(params.ApiUrl, params.ApiKey, params.HttpOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
 )
 
 func TestNewConfigurationAPI(t *testing.T) {
@@ -19,3 +21,17 @@ func TestNewConfigurationAPI(t *testing.T) {
 	assert.Equal(t, "https://api.example.com", configAPI.ApiURL)
 	assert.Equal(t, "apikey123", configAPI.ApiKey)
 }
+
+func TestNewConfigurationWithExecutor(t *testing.T) {
+	executor := &MockHttpExecutor{}
+
+	config, err := NewConfiguration(ConfigurationParams{
+		ChainId:     1,
+		ApiUrl:      "https://api.example.com",
+		ApiKey:      "apikey123",
+		HttpOptions: []common.HttpOption{common.WithExecutor(executor)},
+	})
+
+	assert.NoError(t, err)
+	assert.Same(t, executor, config.API.httpExecutor)
+}
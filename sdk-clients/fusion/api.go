@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 
 	"github.com/1inch/1inch-sdk-go/v4/common"
+	"github.com/1inch/1inch-sdk-go/v4/internal/logging"
 )
 
 func (api *api) GetActiveOrders(ctx context.Context, params OrderApiControllerGetActiveOrdersParams) (*GetActiveOrdersOutput, error) {
@@ -155,6 +157,11 @@ func (api *api) PlaceOrder(ctx context.Context, fusionQuote GetQuoteOutputFixed,
 		return "", err
 	}
 
+	logging.Debug(ctx, api.logger, "fusion order placed",
+		slog.String("orderHash", limitOrder.OrderHash),
+		slog.String("quoteId", fusionQuote.QuoteId),
+		slog.String("maker", limitOrder.Data.Maker))
+
 	return limitOrder.OrderHash, nil
 }
 
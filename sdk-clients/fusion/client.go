@@ -3,6 +3,7 @@ package fusion
 import (
 	"context"
 	"errors"
+	"log/slog"
 
 	"github.com/1inch/1inch-sdk-go/v4/common"
 )
@@ -15,6 +16,7 @@ type Client struct {
 type api struct {
 	chainId      uint64
 	httpExecutor common.HttpExecutor
+	logger       *slog.Logger
 }
 
 func NewClient(cfg *Configuration) (*Client, error) {
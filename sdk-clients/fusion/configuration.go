@@ -5,6 +5,7 @@ import (
 
 	"github.com/1inch/1inch-sdk-go/v4/common"
 	http_executor "github.com/1inch/1inch-sdk-go/v4/internal/http-executor"
+	"github.com/1inch/1inch-sdk-go/v4/internal/logging"
 	web3_provider "github.com/1inch/1inch-sdk-go/v4/internal/web3-provider"
 )
 
@@ -26,14 +27,16 @@ type ConfigurationWallet struct {
 }
 
 type ConfigurationParams struct {
-	ChainId    uint64
-	ApiUrl     string
-	ApiKey     string
-	PrivateKey string
+	ChainId       uint64
+	ApiUrl        string
+	ApiKey        string
+	PrivateKey    string
+	HttpOptions   []common.HttpOption
+	WalletOptions []common.WalletOption
 }
 
 func NewConfiguration(params ConfigurationParams) (*Configuration, error) {
-	executor, err := http_executor.DefaultHttpClient(params.ApiUrl, params.ApiKey)
+	executor, err := http_executor.NewExecutor(params.ApiUrl, params.ApiKey, params.HttpOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -41,9 +44,10 @@ func NewConfiguration(params ConfigurationParams) (*Configuration, error) {
 	a := api{
 		chainId:      params.ChainId,
 		httpExecutor: executor,
+		logger:       logging.New(common.NewHttpConfig(params.HttpOptions...).Logger),
 	}
 
-	walletCfg, err := NewConfigurationWallet(params.PrivateKey, params.ChainId)
+	walletCfg, err := NewConfigurationWallet(params.PrivateKey, params.ChainId, params.WalletOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -58,11 +62,11 @@ func NewConfiguration(params ConfigurationParams) (*Configuration, error) {
 	}, nil
 }
 
-func NewConfigurationWallet(privateKey string, chainId uint64) (*ConfigurationWallet, error) {
-	if privateKey == "" {
-		return nil, fmt.Errorf("private key is required")
+func NewConfigurationWallet(privateKey string, chainId uint64, opts ...common.WalletOption) (*ConfigurationWallet, error) {
+	if privateKey == "" && common.NewWalletConfig(opts...).Signer == nil {
+		return nil, fmt.Errorf("private key or signer is required")
 	}
-	w, err := web3_provider.DefaultWalletOnlyProvider(privateKey, chainId)
+	w, err := web3_provider.DefaultWalletOnlyProvider(privateKey, chainId, opts...)
 	if err != nil {
 		return nil, err
 	}
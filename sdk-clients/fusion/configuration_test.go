@@ -5,6 +5,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+	"github.com/1inch/1inch-sdk-go/v4/common/signer"
 )
 
 func TestNewConfigurationAPI(t *testing.T) {
@@ -21,3 +24,15 @@ func TestNewConfigurationAPI(t *testing.T) {
 	assert.Equal(t, "https://api.example.com", configAPI.APIConfiguration.ApiURL)
 	assert.Equal(t, "apikey123", configAPI.APIConfiguration.ApiKey)
 }
+
+func TestNewConfigurationWallet(t *testing.T) {
+	s, err := signer.NewPrivateKeyFromHex("965e092fdfc08940d2bd05c7b5c7e1c51e283e92c7f52bbf1408973ae9a9acb7")
+	require.NoError(t, err)
+
+	walletCfg, err := NewConfigurationWallet("", 1, common.WithSigner(s))
+	require.NoError(t, err)
+	assert.Equal(t, s.Address(), walletCfg.Wallet.Address())
+
+	_, err = NewConfigurationWallet("", 1)
+	require.EqualError(t, err, "private key or signer is required")
+}
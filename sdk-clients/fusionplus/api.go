@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 
 	"github.com/1inch/1inch-sdk-go/v4/common"
+	"github.com/1inch/1inch-sdk-go/v4/internal/logging"
 )
 
 func (api *api) GetOrderByOrderHash(ctx context.Context, params GetOrderByOrderHashParams) (*GetOrderFillsByHashOutputFixed, error) {
@@ -66,6 +68,8 @@ func (api *api) SubmitSecret(ctx context.Context, params SecretInput) error {
 		return err
 	}
 
+	logging.Debug(ctx, api.logger, "fusion plus secret submitted", slog.String("orderHash", params.OrderHash))
+
 	return nil
 }
 
@@ -161,6 +165,11 @@ func (api *api) PlaceOrder(ctx context.Context, quoteParams QuoterControllerGetQ
 		return "", fmt.Errorf("failed to place order: %w", err)
 	}
 
+	logging.Debug(ctx, api.logger, "fusion plus order placed",
+		slog.String("orderHash", fusionPlusOrder.Hash),
+		slog.String("quoteId", quote.QuoteId),
+		slog.String("maker", fusionPlusOrder.LimitOrder.Data.Maker))
+
 	return fusionPlusOrder.Hash, nil
 }
 
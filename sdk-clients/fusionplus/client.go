@@ -1,6 +1,8 @@
 package fusionplus
 
 import (
+	"log/slog"
+
 	"github.com/1inch/1inch-sdk-go/v4/common"
 )
 
@@ -11,6 +13,7 @@ type Client struct {
 
 type api struct {
 	httpExecutor common.HttpExecutor
+	logger       *slog.Logger
 }
 
 func NewClient(cfg *Configuration) (*Client, error) {
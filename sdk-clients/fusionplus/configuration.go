@@ -5,6 +5,7 @@ import (
 
 	"github.com/1inch/1inch-sdk-go/v4/common"
 	http_executor "github.com/1inch/1inch-sdk-go/v4/internal/http-executor"
+	"github.com/1inch/1inch-sdk-go/v4/internal/logging"
 	web3_provider "github.com/1inch/1inch-sdk-go/v4/internal/web3-provider"
 )
 
@@ -26,22 +27,25 @@ type ConfigurationWallet struct {
 }
 
 type ConfigurationParams struct {
-	ApiUrl     string
-	ApiKey     string
-	PrivateKey string
+	ApiUrl        string
+	ApiKey        string
+	PrivateKey    string
+	HttpOptions   []common.HttpOption
+	WalletOptions []common.WalletOption
 }
 
 func NewConfiguration(params ConfigurationParams) (*Configuration, error) {
-	executor, err := http_executor.DefaultHttpClient(params.ApiUrl, params.ApiKey)
+	executor, err := http_executor.NewExecutor(params.ApiUrl, params.ApiKey, params.HttpOptions...)
 	if err != nil {
 		return nil, err
 	}
 
 	a := api{
 		httpExecutor: executor,
+		logger:       logging.New(common.NewHttpConfig(params.HttpOptions...).Logger),
 	}
 
-	walletCfg, err := NewConfigurationWallet(params.PrivateKey)
+	walletCfg, err := NewConfigurationWallet(params.PrivateKey, params.WalletOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -56,11 +60,11 @@ func NewConfiguration(params ConfigurationParams) (*Configuration, error) {
 	}, nil
 }
 
-func NewConfigurationWallet(privateKey string) (*ConfigurationWallet, error) {
-	if privateKey == "" {
-		return nil, fmt.Errorf("private key is required")
+func NewConfigurationWallet(privateKey string, opts ...common.WalletOption) (*ConfigurationWallet, error) {
+	if privateKey == "" && common.NewWalletConfig(opts...).Signer == nil {
+		return nil, fmt.Errorf("private key or signer is required")
 	}
-	w, err := web3_provider.DefaultWalletOnlyProvider(privateKey, 12345) // TODO Remove this later if possible
+	w, err := web3_provider.DefaultWalletOnlyProvider(privateKey, 12345, opts...) // TODO Remove this later if possible
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,137 @@
+package gasprices
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+)
+
+// Tier is an urgency level of the 1inch gas price API.
+type Tier int
+
+const (
+	TierLow Tier = iota
+	TierMedium
+	TierHigh
+	TierInstant
+)
+
+func (t Tier) String() string {
+	switch t {
+	case TierLow:
+		return "low"
+	case TierMedium:
+		return "medium"
+	case TierHigh:
+		return "high"
+	case TierInstant:
+		return "instant"
+	default:
+		return fmt.Sprintf("Tier(%d)", int(t))
+	}
+}
+
+// gasPriceGetter is the part of *Client that the fee strategy uses
+type gasPriceGetter interface {
+	GetGasPriceEIP1559(ctx context.Context) (*Eip1559GasPriceResponse, error)
+	GetGasPriceLegacy(ctx context.Context) (*GetGasPriceLegacyResponse, error)
+}
+
+var _ gasPriceGetter = (*Client)(nil)
+
+var _ common.FeeStrategy = feeStrategy{}
+
+type feeStrategy struct {
+	client gasPriceGetter
+	tier   Tier
+}
+
+// NewFeeStrategy returns a common.FeeStrategy that pays the fees of tier from the 1inch gas
+// price API, for use with common.WithFeeStrategy.
+// The client must be configured for the wallet's chain. Legacy chains report three tiers,
+// which TierLow and TierMedium both map to the standard price, TierHigh to the fast price
+// and TierInstant to the instant price. On EIP-1559 chains, GasPrice pays the base fee plus
+// the tier's priority fee.
+func NewFeeStrategy(client *Client, tier Tier) common.FeeStrategy {
+	return feeStrategy{client: client, tier: tier}
+}
+
+func (s feeStrategy) DynamicFees(ctx context.Context, _ common.Wallet) (*big.Int, *big.Int, error) {
+	_, tier, err := s.eip1559Tier(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	gasTipCap, err := parseWei("maxPriorityFeePerGas", tier.MaxPriorityFeePerGas)
+	if err != nil {
+		return nil, nil, err
+	}
+	gasFeeCap, err := parseWei("maxFeePerGas", tier.MaxFeePerGas)
+	if err != nil {
+		return nil, nil, err
+	}
+	return gasTipCap, gasFeeCap, nil
+}
+
+func (s feeStrategy) GasPrice(ctx context.Context, wallet common.Wallet) (*big.Int, error) {
+	// The legacy endpoint only serves chains without EIP-1559, so on the others the price is
+	// what the tier would pay at the current base fee
+	if wallet.IsEIP1559Applicable() {
+		prices, tier, err := s.eip1559Tier(ctx)
+		if err != nil {
+			return nil, err
+		}
+		baseFee, err := parseWei("baseFee", prices.BaseFee)
+		if err != nil {
+			return nil, err
+		}
+		gasTipCap, err := parseWei("maxPriorityFeePerGas", tier.MaxPriorityFeePerGas)
+		if err != nil {
+			return nil, err
+		}
+		return baseFee.Add(baseFee, gasTipCap), nil
+	}
+
+	prices, err := s.client.GetGasPriceLegacy(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas prices: %w", err)
+	}
+	switch s.tier {
+	case TierLow, TierMedium:
+		return parseWei("standard", prices.Standard)
+	case TierHigh:
+		return parseWei("fast", prices.Fast)
+	case TierInstant:
+		return parseWei("instant", prices.Instant)
+	default:
+		return nil, fmt.Errorf("unknown gas price tier %s", s.tier)
+	}
+}
+
+func (s feeStrategy) eip1559Tier(ctx context.Context) (*Eip1559GasPriceResponse, Eip1559GasValueResponse, error) {
+	prices, err := s.client.GetGasPriceEIP1559(ctx)
+	if err != nil {
+		return nil, Eip1559GasValueResponse{}, fmt.Errorf("failed to get gas prices: %w", err)
+	}
+	switch s.tier {
+	case TierLow:
+		return prices, prices.Low, nil
+	case TierMedium:
+		return prices, prices.Medium, nil
+	case TierHigh:
+		return prices, prices.High, nil
+	case TierInstant:
+		return prices, prices.Instant, nil
+	default:
+		return nil, Eip1559GasValueResponse{}, fmt.Errorf("unknown gas price tier %s", s.tier)
+	}
+}
+
+func parseWei(name string, value string) (*big.Int, error) {
+	wei, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid %s %q in gas price response", name, value)
+	}
+	return wei, nil
+}
@@ -0,0 +1,113 @@
+package gasprices
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+)
+
+// chainWallet reports whether its chain uses EIP-1559
+type chainWallet struct {
+	common.Wallet
+	eip1559 bool
+}
+
+func (w chainWallet) IsEIP1559Applicable() bool {
+	return w.eip1559
+}
+
+type fakeGasPrices struct {
+	err error
+}
+
+func (f fakeGasPrices) GetGasPriceEIP1559(context.Context) (*Eip1559GasPriceResponse, error) {
+	return &Eip1559GasPriceResponse{
+		BaseFee: "20000000000",
+		Low:     Eip1559GasValueResponse{MaxPriorityFeePerGas: "1000000000", MaxFeePerGas: "25000000000"},
+		Medium:  Eip1559GasValueResponse{MaxPriorityFeePerGas: "1500000000", MaxFeePerGas: "30000000000"},
+		High:    Eip1559GasValueResponse{MaxPriorityFeePerGas: "2000000000", MaxFeePerGas: "40000000000"},
+		Instant: Eip1559GasValueResponse{MaxPriorityFeePerGas: "3000000000", MaxFeePerGas: "not a number"},
+	}, f.err
+}
+
+func (f fakeGasPrices) GetGasPriceLegacy(context.Context) (*GetGasPriceLegacyResponse, error) {
+	return &GetGasPriceLegacyResponse{Standard: "5000000000", Fast: "6000000000", Instant: "7000000000"}, f.err
+}
+
+func TestFeeStrategy(t *testing.T) {
+	tests := []struct {
+		name              string
+		strategy          common.FeeStrategy
+		eip1559Chain      bool
+		expectedGasTipCap *big.Int
+		expectedGasFeeCap *big.Int
+		expectedGasPrice  *big.Int
+		expectedError     string
+	}{
+		{
+			name:              "Low tier",
+			strategy:          feeStrategy{client: fakeGasPrices{}, tier: TierLow},
+			expectedGasTipCap: big.NewInt(1e9),
+			expectedGasFeeCap: big.NewInt(25e9),
+			expectedGasPrice:  big.NewInt(5e9),
+		},
+		{
+			name:              "High tier",
+			strategy:          feeStrategy{client: fakeGasPrices{}, tier: TierHigh},
+			expectedGasTipCap: big.NewInt(2e9),
+			expectedGasFeeCap: big.NewInt(40e9),
+			expectedGasPrice:  big.NewInt(6e9),
+		},
+		{
+			name:              "Low tier on an EIP-1559 chain",
+			strategy:          feeStrategy{client: fakeGasPrices{}, tier: TierLow},
+			eip1559Chain:      true,
+			expectedGasTipCap: big.NewInt(1e9),
+			expectedGasFeeCap: big.NewInt(25e9),
+			expectedGasPrice:  big.NewInt(21e9),
+		},
+		{
+			name:              "High tier on an EIP-1559 chain",
+			strategy:          feeStrategy{client: fakeGasPrices{}, tier: TierHigh},
+			eip1559Chain:      true,
+			expectedGasTipCap: big.NewInt(2e9),
+			expectedGasFeeCap: big.NewInt(40e9),
+			expectedGasPrice:  big.NewInt(22e9),
+		},
+		{
+			name:          "Invalid value",
+			strategy:      feeStrategy{client: fakeGasPrices{}, tier: TierInstant},
+			expectedError: `invalid maxFeePerGas "not a number" in gas price response`,
+		},
+		{
+			name:          "Error",
+			strategy:      feeStrategy{client: fakeGasPrices{err: errors.New("status 500")}, tier: TierMedium},
+			expectedError: "failed to get gas prices: status 500",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			wallet := chainWallet{eip1559: tc.eip1559Chain}
+			gasTipCap, gasFeeCap, err := tc.strategy.DynamicFees(ctx, wallet)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedGasTipCap, gasTipCap)
+			assert.Equal(t, tc.expectedGasFeeCap, gasFeeCap)
+
+			gasPrice, err := tc.strategy.GasPrice(ctx, wallet)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedGasPrice, gasPrice)
+		})
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/1inch/1inch-sdk-go/v4/common"
+	"github.com/1inch/1inch-sdk-go/v4/internal/logging"
 )
 
 // CreateOrder creates an order in the Limit Order Protocol
@@ -46,6 +48,10 @@ func (api *api) CreateOrder(ctx context.Context, params CreateOrderParams) (*Cre
 		return nil, err
 	}
 
+	logging.Debug(ctx, api.logger, "limit order created",
+		slog.String("orderHash", order.OrderHash),
+		slog.String("maker", params.Maker))
+
 	return &createOrderResponse, nil
 }
 
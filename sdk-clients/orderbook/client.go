@@ -1,6 +1,7 @@
 package orderbook
 
 import (
+	"log/slog"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -24,6 +25,7 @@ type ClientOnlyAPI struct {
 type api struct {
 	chainId      uint64
 	httpExecutor common.HttpExecutor
+	logger       *slog.Logger
 }
 
 func NewClient(cfg *Configuration) (*Client, error) {
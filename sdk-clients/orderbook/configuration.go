@@ -3,6 +3,7 @@ package orderbook
 import (
 	"github.com/1inch/1inch-sdk-go/v4/common"
 	http_executor "github.com/1inch/1inch-sdk-go/v4/internal/http-executor"
+	"github.com/1inch/1inch-sdk-go/v4/internal/logging"
 	transaction_builder "github.com/1inch/1inch-sdk-go/v4/internal/transaction-builder"
 	web3_provider "github.com/1inch/1inch-sdk-go/v4/internal/web3-provider"
 )
@@ -28,19 +29,21 @@ type WalletConfiguration struct {
 }
 
 type ConfigurationParams struct {
-	NodeUrl    string
-	PrivateKey string
-	ChainId    uint64
-	ApiUrl     string
-	ApiKey     string
+	NodeUrl       string
+	PrivateKey    string
+	ChainId       uint64
+	ApiUrl        string
+	ApiKey        string
+	HttpOptions   []common.HttpOption
+	WalletOptions []common.WalletOption
 }
 
 func NewConfiguration(params ConfigurationParams) (*Configuration, error) {
-	apiCfg, err := NewConfigurationAPI(params.ChainId, params.ApiUrl, params.ApiKey)
+	apiCfg, err := NewConfigurationAPI(params.ChainId, params.ApiUrl, params.ApiKey, params.HttpOptions...)
 	if err != nil {
 		return nil, err
 	}
-	walletCfg, err := NewConfigurationWallet(params.NodeUrl, params.PrivateKey, params.ChainId)
+	walletCfg, err := NewConfigurationWallet(params.NodeUrl, params.PrivateKey, params.ChainId, params.WalletOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -50,8 +53,8 @@ func NewConfiguration(params ConfigurationParams) (*Configuration, error) {
 	}, nil
 }
 
-func NewConfigurationAPI(chainId uint64, apiUrl string, apiKey string) (*ConfigurationAPI, error) {
-	executor, err := http_executor.DefaultHttpClient(apiUrl, apiKey)
+func NewConfigurationAPI(chainId uint64, apiUrl string, apiKey string, opts ...common.HttpOption) (*ConfigurationAPI, error) {
+	executor, err := http_executor.NewExecutor(apiUrl, apiKey, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -59,6 +62,7 @@ func NewConfigurationAPI(chainId uint64, apiUrl string, apiKey string) (*Configu
 	a := api{
 		chainId:      chainId,
 		httpExecutor: executor,
+		logger:       logging.New(common.NewHttpConfig(opts...).Logger),
 	}
 
 	return &ConfigurationAPI{
@@ -69,13 +73,13 @@ func NewConfigurationAPI(chainId uint64, apiUrl string, apiKey string) (*Configu
 	}, nil
 }
 
-func NewConfigurationWallet(nodeUrl string, privateKey string, chainId uint64) (*WalletConfiguration, error) {
-	w, err := web3_provider.DefaultWalletProvider(privateKey, nodeUrl, chainId)
+func NewConfigurationWallet(nodeUrl string, privateKey string, chainId uint64, opts ...common.WalletOption) (*WalletConfiguration, error) {
+	w, err := web3_provider.DefaultWalletProvider(privateKey, nodeUrl, chainId, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	f := transaction_builder.NewFactory(w)
+	f := transaction_builder.NewFactory(w, opts...)
 	return &WalletConfiguration{
 		Wallet:    w,
 		TxBuilder: f,
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/1inch/1inch-sdk-go/v4/internal/hexadecimal"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
@@ -90,27 +91,16 @@ func CreateLimitOrderMessage(orderRequest CreateOrderParams, chainId int) (*Orde
 		Message: orderMessage,
 	}
 
-	typedDataHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
+	// The order hash is the EIP-712 digest of the order
+	challengeHash, _, err := apitypes.TypedDataAndHash(typedData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to hash typed data: %w", err)
 	}
-	domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
-	if err != nil {
-		return nil, fmt.Errorf("failed to hash domain separator: %w", err)
-	}
-
-	// Add required prefix to the message
-	rawData := []byte{0x19, 0x01}
-	rawData = append(rawData, domainSeparator...)
-	rawData = append(rawData, typedDataHash...)
-
-	challengeHash := crypto.Keccak256Hash(rawData)
-	challengeHashHex := challengeHash.Hex()
+	challengeHashHex := hexutil.Encode(challengeHash)
 
-	// Sign the challenge hash
-	signature, err := orderRequest.Wallet.SignBytes(challengeHash.Bytes())
+	signature, err := orderRequest.Wallet.SignTypedData(typedData)
 	if err != nil {
-		return nil, fmt.Errorf("failed to sign challenge hash: %w", err)
+		return nil, fmt.Errorf("failed to sign order: %w", err)
 	}
 
 	// add 27 to `v` value (last byte)
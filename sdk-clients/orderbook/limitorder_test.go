@@ -1,12 +1,17 @@
 package orderbook
 
 import (
+	"context"
+	"errors"
 	"math/big"
 	"testing"
 
-	web3_provider "github.com/1inch/1inch-sdk-go/v4/internal/web3-provider"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/v4/common"
+	"github.com/1inch/1inch-sdk-go/v4/common/signer"
+	web3_provider "github.com/1inch/1inch-sdk-go/v4/internal/web3-provider"
 )
 
 func TestGenerateSalt(t *testing.T) {
@@ -139,6 +144,10 @@ func TestPrivateKeyProviderSignatures(t *testing.T) {
 
 	wallet, err := web3_provider.DefaultWalletOnlyProvider(testPrivateKey, 1)
 	require.NoError(t, err)
+	privateKeySigner, err := signer.NewPrivateKeyFromHex(testPrivateKey)
+	require.NoError(t, err)
+	typedDataWallet, err := web3_provider.DefaultWalletOnlyProvider("", 1, common.WithSigner(typedDataOnlySigner{privateKeySigner}))
+	require.NoError(t, err)
 
 	tests := []struct {
 		name              string
@@ -160,6 +169,21 @@ func TestPrivateKeyProviderSignatures(t *testing.T) {
 			},
 			expectedSignature: "0x8e1cbdc41ebb253aea91bfa41a028e735be4a5b25d93da0e3a6817070f40dcd31dfbc38bd3800ce2ff88089c77ca2f442dc84637006808aab0af00d966c917b11b",
 		},
+		{
+			name: "Signer that only signs typed data",
+			createOrderParams: CreateOrderParams{
+				Wallet:           typedDataWallet,
+				Salt:             "618054093254",
+				MakerAsset:       "0xe9e7cea3dedca5984780bafc599bd69add087d56",
+				TakerAsset:       "0x111111111117dc0aa78b770fa6a738034120c302",
+				Maker:            "0xfb3c7eb936cAA12B5A884d612393969A557d4307",
+				Taker:            "0x0000000000000000000000000000000000000000",
+				MakingAmount:     "1000000000000000000",
+				TakingAmount:     "1000000000000000000",
+				ExtensionEncoded: "",
+			},
+			expectedSignature: "0x8e1cbdc41ebb253aea91bfa41a028e735be4a5b25d93da0e3a6817070f40dcd31dfbc38bd3800ce2ff88089c77ca2f442dc84637006808aab0af00d966c917b11b",
+		},
 	}
 
 	for _, tc := range tests {
@@ -170,3 +194,12 @@ func TestPrivateKeyProviderSignatures(t *testing.T) {
 		})
 	}
 }
+
+// typedDataOnlySigner refuses raw digests, like remote signers do
+type typedDataOnlySigner struct {
+	common.Signer
+}
+
+func (typedDataOnlySigner) SignHash(context.Context, []byte) ([]byte, error) {
+	return nil, errors.ErrUnsupported
+}
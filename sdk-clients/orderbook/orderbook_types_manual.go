@@ -31,8 +31,12 @@ type GetOrdersByCreatorAddressParams struct {
 }
 
 type GetOrderParams struct {
-	OrderHash               string
-	SleepBetweenSubrequests bool // For free accounts, this should be set to true to avoid 429 errors when using the GetOrderWithSignature method
+	OrderHash string
+	// SleepBetweenSubrequests pauses for one second between the two requests made by GetOrderWithSignature.
+	//
+	// Deprecated: configure a shared rate limiter with common.WithRateLimiter instead, which paces
+	// every request made with the API key rather than just this one.
+	SleepBetweenSubrequests bool
 }
 
 type GetAllOrdersParams struct {
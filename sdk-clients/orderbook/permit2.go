@@ -168,14 +168,9 @@ func signPermit2PermitSingle(wallet common.Wallet, params Permit2PermitParams) (
 		},
 	}
 
-	digest, _, err := apitypes.TypedDataAndHash(typedData)
+	signature, err := wallet.SignTypedData(typedData)
 	if err != nil {
-		return nil, fmt.Errorf("failed to hash PermitSingle typed data: %w", err)
-	}
-
-	signature, err := wallet.SignBytes(digest)
-	if err != nil {
-		return nil, fmt.Errorf("failed to sign PermitSingle digest: %w", err)
+		return nil, fmt.Errorf("failed to sign PermitSingle: %w", err)
 	}
 	if len(signature) != 65 {
 		return nil, fmt.Errorf("unexpected signature length %d, want 65", len(signature))
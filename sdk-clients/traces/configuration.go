@@ -1,6 +1,7 @@
 package traces
 
 import (
+	"github.com/1inch/1inch-sdk-go/v4/common"
 	http_executor "github.com/1inch/1inch-sdk-go/v4/internal/http-executor"
 )
 
@@ -10,8 +11,8 @@ type Configuration struct {
 	API    api
 }
 
-func NewConfiguration(chainId uint64, apiUrl string, apiKey string) (*Configuration, error) {
-	executor, err := http_executor.DefaultHttpClient(apiUrl, apiKey)
+func NewConfiguration(chainId uint64, apiUrl string, apiKey string, opts ...common.HttpOption) (*Configuration, error) {
+	executor, err := http_executor.NewExecutor(apiUrl, apiKey, opts...)
 	if err != nil {
 		return nil, err
 	}